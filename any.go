@@ -0,0 +1,244 @@
+package anyarrow
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+)
+
+// Any provides access to the elements of any [arrow.Array] as go values,
+// for generic tooling such as printers and exporters.
+//
+// The elements are converted as
+//   - nulls to nil.
+//   - booleans to bool, signed integers to int64 and unsigned integers to uint64,
+//     floats and decimals to float64, read by [Int64], [Uint64] and [Float64].
+//   - strings to string read by [String], and binaries to []byte sharing the memory of the array.
+//   - timestamps, date32 and date64 to time.Time, durations to time.Duration,
+//     and time32 and time64 to the time.Duration since midnight.
+//   - lists to []any, and structs to map[string]any by field name.
+//   - maps to map[string]any, where the keys that are not strings are formatted by [fmt.Sprint].
+//   - dictionaries, run-end encoded and extension arrays to the values they hold.
+//   - other types, such as intervals and unions, to the value of [arrow.Array.GetOneForMarshal].
+type Any struct {
+	arrowArray
+
+	value func(i int) any
+	// children are the accessors value reads from, released with the accessor.
+	children []interface{ Release() }
+
+	refCount int64
+}
+
+var _ arrow.Array = (*Any)(nil)
+
+// NewAny wraps the provided [arrow.Array] of any type.
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewAny(a arrow.Array) (*Any, error) {
+	r := &Any{arrowArray: arrowArray{Array: a}, refCount: 1}
+	value, err := r.valueFunc(a)
+	if err != nil {
+		r.releaseChildren()
+		return nil, err
+	}
+	r.value = value
+	a.Retain()
+
+	return r, nil
+}
+
+// Value retrieves the element at index i, which is nil if the element is null.
+func (a *Any) Value(i int) any {
+	return a.value(i)
+}
+
+// Retain increases the reference count of the accessor by 1.
+func (a *Any) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
+
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Any) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.releaseChildren()
+		a.Array.Release()
+		*a = Any{}
+	}
+}
+
+func (a *Any) releaseChildren() {
+	for _, c := range a.children {
+		c.Release()
+	}
+	a.children = nil
+}
+
+// valueFunc returns the function retrieving the elements of arr, including nulls.
+func (a *Any) valueFunc(arr arrow.Array) (func(int) any, error) {
+	value, err := a.validValueFunc(arr)
+	if err != nil || arr.NullN() == 0 {
+		return value, err
+	}
+
+	return func(i int) any {
+		if arr.IsNull(i) {
+			return nil
+		}
+		return value(i)
+	}, nil
+}
+
+// binaryValuer is implemented by [array.Binary], [array.LargeBinary] and [array.FixedSizeBinary].
+type binaryValuer interface {
+	Value(i int) []byte
+}
+
+// validValueFunc returns the function retrieving the elements of arr that are not null.
+func (a *Any) validValueFunc(arr arrow.Array) (func(int) any, error) {
+	switch dt := arr.DataType(); dt.ID() {
+	case arrow.NULL:
+		return func(int) any { return nil }, nil
+	case arrow.BOOL:
+		v := arr.(*array.Boolean)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		v, err := NewInt64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		v, err := NewUint64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.FLOAT16:
+		v := arr.(*array.Float16)
+		return func(i int) any { return float64(v.Value(i).Float32()) }, nil
+	case arrow.FLOAT32, arrow.FLOAT64, arrow.DECIMAL128, arrow.DECIMAL256:
+		v, err := NewFloat64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.STRING, arrow.LARGE_STRING:
+		v, err := NewString(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.BINARY, arrow.LARGE_BINARY, arrow.FIXED_SIZE_BINARY:
+		v := arr.(binaryValuer)
+		return func(i int) any { return v.Value(i) }, nil
+	case arrow.TIMESTAMP, arrow.DATE32, arrow.DATE64:
+		toTime, err := TimeFunc(dt)
+		if err != nil {
+			return nil, err
+		}
+		v, err := NewInt64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return toTime(v.Value(i)) }, nil
+	case arrow.DURATION:
+		toDuration, err := DurationFunc(dt)
+		if err != nil {
+			return nil, err
+		}
+		v, err := NewInt64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return toDuration(v.Value(i)) }, nil
+	case arrow.TIME32, arrow.TIME64:
+		unit := time.Duration(dt.(arrow.TemporalWithUnit).TimeUnit().Multiplier())
+		v, err := NewInt64(arr)
+		if err != nil {
+			return nil, err
+		}
+		a.children = append(a.children, v)
+		return func(i int) any { return time.Duration(v.Value(i)) * unit }, nil
+	}
+
+	switch v := arr.(type) {
+	case *array.Map:
+		keys, err := a.valueFunc(v.Keys())
+		if err != nil {
+			return nil, err
+		}
+		items, err := a.valueFunc(v.Items())
+		if err != nil {
+			return nil, err
+		}
+		return func(i int) any {
+			start, end := v.ValueOffsets(i)
+			m := make(map[string]any, end-start)
+			for j := int(start); j < int(end); j++ {
+				k := keys(j)
+				s, ok := k.(string)
+				if !ok {
+					s = fmt.Sprint(k)
+				}
+				m[s] = items(j)
+			}
+			return m
+		}, nil
+	case array.ListLike:
+		elems, err := a.valueFunc(v.ListValues())
+		if err != nil {
+			return nil, err
+		}
+		return func(i int) any {
+			start, end := v.ValueOffsets(i)
+			l := make([]any, 0, end-start)
+			for j := int(start); j < int(end); j++ {
+				l = append(l, elems(j))
+			}
+			return l
+		}, nil
+	case *array.Struct:
+		fields := v.DataType().(*arrow.StructType).Fields()
+		values := make([]func(int) any, len(fields))
+		for j := range fields {
+			var err error
+			if values[j], err = a.valueFunc(v.Field(j)); err != nil {
+				return nil, err
+			}
+		}
+		return func(i int) any {
+			m := make(map[string]any, len(fields))
+			for j, f := range fields {
+				m[f.Name] = values[j](i)
+			}
+			return m
+		}, nil
+	case *array.Dictionary:
+		values, err := a.valueFunc(v.Dictionary())
+		if err != nil {
+			return nil, err
+		}
+		return func(i int) any { return values(v.GetValueIndex(i)) }, nil
+	case *array.RunEndEncoded:
+		values, err := a.valueFunc(v.Values())
+		if err != nil {
+			return nil, err
+		}
+		return func(i int) any { return values(v.GetPhysicalIndex(i)) }, nil
+	case array.ExtensionArray:
+		return a.valueFunc(v.Storage())
+	default:
+		return arr.GetOneForMarshal, nil
+	}
+}
@@ -0,0 +1,110 @@
+package anyarrow_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestAny(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tests := []struct {
+		dt       arrow.DataType
+		json     string
+		expected []any
+	}{
+		{arrow.FixedWidthTypes.Boolean, `[true, null]`, []any{true, nil}},
+		{arrow.PrimitiveTypes.Int16, `[-1, null, 2]`, []any{int64(-1), nil, int64(2)}},
+		{arrow.PrimitiveTypes.Uint32, `[4000000000]`, []any{uint64(4000000000)}},
+		{arrow.PrimitiveTypes.Float32, `[1.5]`, []any{1.5}},
+		{arrow.FixedWidthTypes.Float16, `[0.5]`, []any{0.5}},
+		{&arrow.Decimal128Type{Precision: 10, Scale: 2}, `["12.25"]`, []any{12.25}},
+		{arrow.BinaryTypes.LargeString, `["a", null]`, []any{"a", nil}},
+		{arrow.BinaryTypes.Binary, `["aGk="]`, []any{[]byte("hi")}},
+		{arrow.FixedWidthTypes.Timestamp_ms, `[1000]`, []any{time.Unix(1, 0).UTC()}},
+		{arrow.FixedWidthTypes.Date32, `[1]`, []any{time.Unix(86400, 0).UTC()}},
+		{arrow.FixedWidthTypes.Duration_us, `[1500]`, []any{1500 * time.Microsecond}},
+		{arrow.FixedWidthTypes.Time32s, `[3600]`, []any{time.Hour}},
+		{arrow.Null, `[null, null]`, []any{nil, nil}},
+		{
+			arrow.ListOf(arrow.PrimitiveTypes.Int64),
+			`[[1, null], null, []]`,
+			[]any{[]any{int64(1), nil}, nil, []any{}},
+		},
+		{
+			arrow.StructOf(arrow.Field{Name: "a", Type: arrow.BinaryTypes.String, Nullable: true}, arrow.Field{Name: "b", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)}),
+			`[{"a": "x", "b": [1]}, null, {"a": null, "b": []}]`,
+			[]any{map[string]any{"a": "x", "b": []any{1.0}}, nil, map[string]any{"a": nil, "b": []any{}}},
+		},
+		{
+			arrow.MapOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String),
+			`[[{"key": 1, "value": "one"}]]`,
+			[]any{map[string]any{"1": "one"}},
+		},
+		{arrow.FixedWidthTypes.MonthInterval, `[{"months": 3}]`, []any{arrow.MonthInterval(3)}},
+	}
+
+	for _, test := range tests {
+		arr, _, err := array.FromJSON(mem, test.dt, strings.NewReader(test.json))
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		a, err := anyarrow.NewAny(arr)
+		arr.Release()
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		for i, expected := range test.expected {
+			if v := a.Value(i); !reflect.DeepEqual(v, expected) {
+				t.Errorf("%s at %d: expected %#v, got %#v", test.dt, i, expected, v)
+			}
+		}
+		a.Release()
+	}
+}
+
+func TestAnyEncoded(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	strs := anyarrow.FromStrings(mem, []string{"a", "b"}, nil)
+	defer strs.Release()
+	dict := newDictionary(t, mem, []int16{1, 0, 1}, []bool{true, false, true}, strs)
+	defer dict.Release()
+
+	ends := anyarrow.FromSlice([]int32{2, 3}, nil)
+	defer ends.Release()
+	values := anyarrow.FromSlice([]int8{7, 8}, nil)
+	defer values.Release()
+	ree := array.NewRunEndEncodedArray(ends, values, 3, 0)
+	defer ree.Release()
+
+	tests := []struct {
+		arr      arrow.Array
+		expected []any
+	}{
+		{dict, []any{"b", nil, "b"}},
+		{ree, []any{int64(7), int64(7), int64(8)}},
+	}
+	for _, test := range tests {
+		a, err := anyarrow.NewAny(test.arr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, expected := range test.expected {
+			if v := a.Value(i); !reflect.DeepEqual(v, expected) {
+				t.Errorf("%s at %d: expected %#v, got %#v", test.arr.DataType(), i, expected, v)
+			}
+		}
+		a.Release()
+	}
+}
@@ -0,0 +1,392 @@
+package anyarrow
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/decimal256"
+)
+
+// Appendable is the set of go types that can be appended by an [Appender].
+type Appendable interface {
+	FixedWidth | ~string
+}
+
+// Appender appends go values of type T to an [array.Builder], converting them to the type of the builder.
+//
+// It is the write side of the accessors: numbers are converted by go conversions,
+// so they wrap around or truncate toward zero the same way as the accessors read them.
+// Specifically,
+//   - numbers can be appended to numeric, timestamp, duration, date and time builders,
+//     which receive the raw values the accessors read, for example the milliseconds of a timestamp[ms].
+//   - numbers are appended to boolean builders as true if they are not zero.
+//   - integers and floats are appended to decimal builders with the scale of the builder,
+//     which rounds them for floats and negative scales, and fails if they overflow the precision.
+//   - strings can be appended to string and binary builders.
+//   - dictionary builders accept the values their value types accept.
+type Appender[T Appendable] struct {
+	builder     array.Builder
+	appendValue func(T) error
+}
+
+// NewAppender creates an [Appender] appending go values of type T to b.
+//
+// An [*UnsupportedTypeError] is returned if values of T cannot be appended to the builder.
+// The appender doesn't retain b, and b must outlive the appender.
+func NewAppender[T Appendable](b array.Builder) (*Appender[T], error) {
+	appendValue, err := newAppendFunc[T](b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Appender[T]{builder: b, appendValue: appendValue}, nil
+}
+
+// Builder returns the builder of the appender.
+func (a *Appender[T]) Builder() array.Builder {
+	return a.builder
+}
+
+// Append appends v to the builder.
+//
+// An [*OverflowError] is only returned when v cannot be represented by the builder, such as a float overflowing a decimal,
+// and nothing is appended in that case.
+func (a *Appender[T]) Append(v T) error {
+	return a.appendValue(v)
+}
+
+// AppendNull appends a null to the builder.
+func (a *Appender[T]) AppendNull() {
+	a.builder.AppendNull()
+}
+
+// AppendNullable appends v.Value to the builder if v is valid, otherwise appends a null.
+func (a *Appender[T]) AppendNullable(v Nullable[T]) error {
+	if !v.Valid {
+		a.builder.AppendNull()
+		return nil
+	}
+
+	return a.appendValue(v.Value)
+}
+
+// AppendValues appends the values vs to the builder, where vs[i] is appended as null if valid[i] is false.
+// valid must be either nil, in which case all values are valid, or have the same length as vs.
+//
+// When an error is returned, the values before the failing one have been appended.
+func (a *Appender[T]) AppendValues(vs []T, valid []bool) error {
+	if valid != nil && len(valid) != len(vs) {
+		return fmt.Errorf("values and valid have different lengths %d and %d", len(vs), len(valid))
+	}
+
+	a.builder.Reserve(len(vs))
+	for i, v := range vs {
+		if valid != nil && !valid[i] {
+			a.builder.AppendNull()
+			continue
+		}
+		if err := a.appendValue(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newAppendFunc returns the function appending T to b.
+func newAppendFunc[T Appendable](b array.Builder) (func(T) error, error) {
+	t := reflect.TypeFor[T]()
+	k := t.Kind()
+	if k == reflect.String {
+		toString := func(v T) string { return *(*string)(unsafe.Pointer(&v)) }
+		switch b := b.(type) {
+		case *array.StringBuilder:
+			return func(v T) error { b.Append(toString(v)); return nil }, nil
+		case *array.LargeStringBuilder:
+			return func(v T) error { b.Append(toString(v)); return nil }, nil
+		case *array.BinaryBuilder:
+			return func(v T) error { b.AppendString(toString(v)); return nil }, nil
+		case *array.BinaryDictionaryBuilder:
+			return func(v T) error { return b.AppendString(toString(v)) }, nil
+		}
+
+		return nil, &UnsupportedTypeError{DataType: b.Type(), GoType: t}
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Int16Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Int32Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Int64Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Uint8Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Uint16Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Uint32Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Uint64Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Float32Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Float64Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.TimestampBuilder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.DurationBuilder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Date32Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Date64Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Time32Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.Time64Builder:
+		return appendNumber[T](k, b.Append), nil
+	case *array.BooleanBuilder:
+		toFloat := convertFunc[T, float64](k)
+		return func(v T) error { b.Append(toFloat(v) != 0); return nil }, nil
+	case *array.Decimal128Builder:
+		toDecimal := decimal128Func[T](k, b.Type().(arrow.DecimalType), b)
+		return func(v T) error {
+			n, err := toDecimal(v)
+			if err == nil {
+				b.Append(n)
+			}
+			return err
+		}, nil
+	case *array.Decimal256Builder:
+		toDecimal := decimal256Func[T](k, b.Type().(arrow.DecimalType), b)
+		return func(v T) error {
+			n, err := toDecimal(v)
+			if err == nil {
+				b.Append(n)
+			}
+			return err
+		}, nil
+	case *array.Int8DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Int16DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Int32DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Int64DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Uint8DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Uint16DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Uint32DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Uint64DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Float32DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Float64DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.TimestampDictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.DurationDictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Date32DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Date64DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Time32DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Time64DictionaryBuilder:
+		return appendNumberErr[T](k, b.Append), nil
+	case *array.Decimal128DictionaryBuilder:
+		toDecimal := decimal128Func[T](k, b.Type().(*arrow.DictionaryType).ValueType.(arrow.DecimalType), b)
+		return func(v T) error {
+			n, err := toDecimal(v)
+			if err != nil {
+				return err
+			}
+			return b.Append(n)
+		}, nil
+	case *array.Decimal256DictionaryBuilder:
+		toDecimal := decimal256Func[T](k, b.Type().(*arrow.DictionaryType).ValueType.(arrow.DecimalType), b)
+		return func(v T) error {
+			n, err := toDecimal(v)
+			if err != nil {
+				return err
+			}
+			return b.Append(n)
+		}, nil
+	}
+
+	return nil, &UnsupportedTypeError{DataType: b.Type(), GoType: t}
+}
+
+// appendNumber returns the function appending T to a builder by add, after converting T to D by a go conversion.
+func appendNumber[T any, D number](k reflect.Kind, add func(D)) func(T) error {
+	conv := convertFunc[T, D](k)
+
+	return func(v T) error {
+		add(conv(v))
+		return nil
+	}
+}
+
+// appendNumberErr is [appendNumber] for builders whose add can fail, such as dictionary builders.
+func appendNumberErr[T any, D number](k reflect.Kind, add func(D) error) func(T) error {
+	conv := convertFunc[T, D](k)
+
+	return func(v T) error {
+		return add(conv(v))
+	}
+}
+
+// valueAs reinterprets v as S, which must be the underlying type of T.
+func valueAs[S, T any](v T) S {
+	return *(*S)(unsafe.Pointer(&v))
+}
+
+// convertFunc returns the function converting T, whose kind is k, to D by a go conversion.
+func convertFunc[T any, D number](k reflect.Kind) func(T) D {
+	switch k {
+	case reflect.Int8:
+		return func(v T) D { return D(valueAs[int8](v)) }
+	case reflect.Int16:
+		return func(v T) D { return D(valueAs[int16](v)) }
+	case reflect.Int32:
+		return func(v T) D { return D(valueAs[int32](v)) }
+	case reflect.Int64:
+		return func(v T) D { return D(valueAs[int64](v)) }
+	case reflect.Int:
+		return func(v T) D { return D(valueAs[int](v)) }
+	case reflect.Uint8:
+		return func(v T) D { return D(valueAs[uint8](v)) }
+	case reflect.Uint16:
+		return func(v T) D { return D(valueAs[uint16](v)) }
+	case reflect.Uint32:
+		return func(v T) D { return D(valueAs[uint32](v)) }
+	case reflect.Uint64:
+		return func(v T) D { return D(valueAs[uint64](v)) }
+	case reflect.Uint:
+		return func(v T) D { return D(valueAs[uint](v)) }
+	case reflect.Float32:
+		return func(v T) D { return D(valueAs[float32](v)) }
+	case reflect.Float64:
+		return func(v T) D { return D(valueAs[float64](v)) }
+	default:
+		panic("unsupported kind " + k.String())
+	}
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isUnsignedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return true
+	default:
+		return false
+	}
+}
+
+// decimal128Func returns the function converting T, whose kind is k, to decimal128 with the precision and scale of dt,
+// which is the value type of builder b.
+func decimal128Func[T any](k reflect.Kind, dt arrow.DecimalType, b array.Builder) func(T) (decimal128.Num, error) {
+	precision, scale := dt.GetPrecision(), dt.GetScale()
+	switch {
+	case isFloatKind(k):
+		toFloat := convertFunc[T, float64](k)
+		return func(v T) (decimal128.Num, error) {
+			n, err := decimal128.FromFloat64(toFloat(v), precision, scale)
+			if err != nil {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	case isUnsignedKind(k):
+		toUint := convertFunc[T, uint64](k)
+		return func(v T) (decimal128.Num, error) {
+			n, ok := rescale128(decimal128.FromU64(toUint(v)), precision, scale)
+			if !ok {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	default:
+		toInt := convertFunc[T, int64](k)
+		return func(v T) (decimal128.Num, error) {
+			n, ok := rescale128(decimal128.FromI64(toInt(v)), precision, scale)
+			if !ok {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	}
+}
+
+// rescale128 sets the scale of the integer n to scale, and reports whether the result fits in precision.
+// n is rounded to the nearest multiple of 10^-scale, half away from zero, for negative scales.
+func rescale128(n decimal128.Num, precision, scale int32) (decimal128.Num, bool) {
+	if scale < 0 {
+		n = n.ReduceScaleBy(-scale, true)
+		return n, n.FitsInPrecision(precision)
+	}
+
+	// n fits if it has at most precision-scale digits, checked before scaling, which wraps around if n overflows.
+	fits := n == decimal128.Num{} || scale < precision && n.FitsInPrecision(precision-scale)
+
+	return n.IncreaseScaleBy(scale), fits
+}
+
+// decimal256Func returns the function converting T, whose kind is k, to decimal256 with the precision and scale of dt,
+// which is the value type of builder b.
+func decimal256Func[T any](k reflect.Kind, dt arrow.DecimalType, b array.Builder) func(T) (decimal256.Num, error) {
+	precision, scale := dt.GetPrecision(), dt.GetScale()
+	switch {
+	case isFloatKind(k):
+		toFloat := convertFunc[T, float64](k)
+		return func(v T) (decimal256.Num, error) {
+			n, err := decimal256.FromFloat64(toFloat(v), precision, scale)
+			if err != nil {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	case isUnsignedKind(k):
+		toUint := convertFunc[T, uint64](k)
+		return func(v T) (decimal256.Num, error) {
+			n, ok := rescale256(decimal256.FromU64(toUint(v)), precision, scale)
+			if !ok {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	default:
+		toInt := convertFunc[T, int64](k)
+		return func(v T) (decimal256.Num, error) {
+			n, ok := rescale256(decimal256.FromI64(toInt(v)), precision, scale)
+			if !ok {
+				return n, &OverflowError{DataType: b.Type(), GoType: reflect.TypeFor[T](), Index: b.Len(), Value: v}
+			}
+			return n, nil
+		}
+	}
+}
+
+// rescale256 sets the scale of the integer n to scale, and reports whether the result fits in precision, see rescale128.
+func rescale256(n decimal256.Num, precision, scale int32) (decimal256.Num, bool) {
+	if scale < 0 {
+		n = n.ReduceScaleBy(-scale, true)
+		return n, n.FitsInPrecision(precision)
+	}
+
+	fits := n == decimal256.Num{} || scale < precision && n.FitsInPrecision(precision-scale)
+
+	return n.IncreaseScaleBy(scale), fits
+}
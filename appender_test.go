@@ -0,0 +1,196 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+type Quantity int
+
+func TestAppender(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tests := []struct {
+		name     string
+		dt       arrow.DataType
+		expected []int64
+	}{
+		{name: "int8", dt: arrow.PrimitiveTypes.Int8, expected: []int64{1, -1, 0, 44}},
+		{name: "uint16", dt: arrow.PrimitiveTypes.Uint16, expected: []int64{1, 65535, 0, 300}},
+		{name: "float32", dt: arrow.PrimitiveTypes.Float32, expected: []int64{1, -1, 0, 300}},
+		{name: "timestamp", dt: arrow.FixedWidthTypes.Timestamp_ms, expected: []int64{1, -1, 0, 300}},
+		{name: "date32", dt: arrow.FixedWidthTypes.Date32, expected: []int64{1, -1, 0, 300}},
+		{name: "boolean", dt: arrow.FixedWidthTypes.Boolean, expected: []int64{1, 1, 0, 1}},
+		{name: "decimal128", dt: &arrow.Decimal128Type{Precision: 10, Scale: 2}, expected: []int64{1, -1, 0, 300}},
+		{name: "decimal256", dt: &arrow.Decimal256Type{Precision: 40, Scale: 3}, expected: []int64{1, -1, 0, 300}},
+		{name: "negative scale decimal128", dt: &arrow.Decimal128Type{Precision: 10, Scale: -2}, expected: []int64{0, 0, 0, 300}},
+		{name: "negative scale decimal256", dt: &arrow.Decimal256Type{Precision: 40, Scale: -2}, expected: []int64{0, 0, 0, 300}},
+		{
+			name:     "dictionary",
+			dt:       &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int16},
+			expected: []int64{1, -1, 0, 300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := array.NewBuilder(mem, tt.dt)
+			defer b.Release()
+
+			a, err := anyarrow.NewAppender[Quantity](b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := a.AppendValues([]Quantity{1, -1, 2}, []bool{true, true, false}); err != nil {
+				t.Fatal(err)
+			}
+			if err := a.Append(300); err != nil {
+				t.Fatal(err)
+			}
+			if err := a.AppendNullable(anyarrow.Nullable[Quantity]{Value: 5}); err != nil {
+				t.Fatal(err)
+			}
+
+			arr := b.NewArray()
+			defer arr.Release()
+			i64, err := anyarrow.NewInt64(arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer i64.Release()
+
+			if i64.Len() != len(tt.expected)+1 {
+				t.Fatalf("expected %d elements, got %d", len(tt.expected)+1, i64.Len())
+			}
+			for i, expected := range tt.expected {
+				if i == 2 {
+					if i64.IsValid(i) {
+						t.Errorf("expected null at %d", i)
+					}
+					continue
+				}
+				if v := i64.Value(i); v != expected {
+					t.Errorf("at %d: expected %d, got %d", i, expected, v)
+				}
+			}
+			if i64.IsValid(len(tt.expected)) {
+				t.Error("expected invalid Nullable to be appended as null")
+			}
+		})
+	}
+}
+
+func TestAppenderString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	type Symbol string
+	for _, dt := range []arrow.DataType{
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.LargeString,
+		arrow.BinaryTypes.Binary,
+		arrow.BinaryTypes.LargeBinary,
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String},
+	} {
+		t.Run(dt.String(), func(t *testing.T) {
+			b := array.NewBuilder(mem, dt)
+			defer b.Release()
+
+			a, err := anyarrow.NewAppender[Symbol](b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := a.AppendValues([]Symbol{"a", "b", "a"}, nil); err != nil {
+				t.Fatal(err)
+			}
+			a.AppendNull()
+
+			arr := b.NewArray()
+			defer arr.Release()
+			s, err := anyarrow.NewStringOf[Symbol](arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Release()
+
+			for i, expected := range []anyarrow.Nullable[Symbol]{{Value: "a", Valid: true}, {Value: "b", Valid: true}, {Value: "a", Valid: true}, {}} {
+				if v, ok := s.ValueOk(i); v != expected.Value || ok != expected.Valid {
+					t.Errorf("at %d: expected %v, got (%q, %t)", i, expected, v, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestAppenderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	if _, err := anyarrow.NewAppender[int64](sb); err == nil {
+		t.Error("expected error appending int64 to string builder")
+	}
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	if _, err := anyarrow.NewAppender[string](ib); err == nil {
+		t.Error("expected error appending string to int64 builder")
+	}
+
+	lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+	defer lb.Release()
+	if _, err := anyarrow.NewAppender[int64](lb); err == nil {
+		t.Error("expected error appending int64 to list builder")
+	}
+
+	db := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 3, Scale: 2})
+	defer db.Release()
+	a, err := anyarrow.NewAppender[float64](db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AppendValues([]float64{1.5, 100}, nil); err == nil {
+		t.Error("expected error appending 100 to decimal(3, 2)")
+	}
+	if db.Len() != 1 {
+		t.Errorf("expected 1 value appended before the error, got %d", db.Len())
+	}
+	if err := a.AppendValues([]float64{1}, []bool{true, false}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+
+	for _, dt := range []arrow.DataType{
+		&arrow.Decimal128Type{Precision: 3, Scale: 2},
+		&arrow.Decimal256Type{Precision: 3, Scale: 2},
+		&arrow.Decimal128Type{Precision: 2, Scale: 2},
+		&arrow.Decimal128Type{Precision: 2, Scale: -2},
+	} {
+		b := array.NewBuilder(mem, dt)
+		defer b.Release()
+		a, err := anyarrow.NewAppender[int64](b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Append(0); err != nil {
+			t.Errorf("%s: unexpected error appending 0: %v", dt, err)
+		}
+		var overflow *anyarrow.OverflowError
+		if err := a.Append(10000); !errors.As(err, &overflow) {
+			t.Errorf("%s: expected overflow error appending 10000, got %v", dt, err)
+		}
+		if err := a.Append(-10000); !errors.As(err, &overflow) {
+			t.Errorf("%s: expected overflow error appending -10000, got %v", dt, err)
+		}
+		if b.Len() != 1 {
+			t.Errorf("%s: expected 1 value appended, got %d", dt, b.Len())
+		}
+	}
+}
@@ -2,9 +2,14 @@ package anyarrow
 
 import (
 	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sync/atomic"
 
 	"github.com/apache/arrow/go/v15/arrow"
 	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/bitutil"
 )
 
 type arrowArray struct {
@@ -12,3761 +17,7445 @@ type arrowArray struct {
 }
 
 // Byte provides convenient access to [arrow.Array]'s element as byte
-type Byte struct {
+type Byte = ByteOf[byte]
+
+// ByteOf provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is byte.
+type ByteOf[T ~byte] struct {
 	arrowArray
 
-	direct  *array.Uint8
-	getFunc func(int) byte
+	direct *array.Uint8
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Byte)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Uint8].
-func (a *Byte) IsDirect() bool {
+func (a *ByteOf[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as byte
-func (a *Byte) Value(i int) byte {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type byte")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *ByteOf[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Uint8"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewByte wraps the provided [arrow.Array].
-func NewByte(a arrow.Array) (*Byte, error) {
-	if direct, ok := a.(*array.Uint8); ok {
-		return &Byte{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *ByteOf[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.u8[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Byte{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *ByteOf[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *ByteOf[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *ByteOf[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *ByteOf[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *ByteOf[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type byte")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *ByteOf[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type byte")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *ByteOf[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) byte {
-			return byte(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *ByteOf[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *ByteOf[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
+
+	return i
+}
 
-			return r, nil
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *ByteOf[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *ByteOf[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.value(j)
+}
 
-			return r, nil
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *ByteOf[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *ByteOf[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			return r, nil
+		return n
+	}
 
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type byte")
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *ByteOf[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			return r, nil
+	return dst
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to byte keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *ByteOf[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			return r, nil
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *ByteOf[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt8:
+			return reinterpretSlice[T](a.values.i8), true
+		case kindUint8:
+			return reinterpretSlice[T](a.values.u8), true
+		}
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return nil, false
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *ByteOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			return r, nil
-
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *ByteOf[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
-
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *ByteOf[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *ByteOf[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewByte wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as byte,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewByte(a arrow.Array, opts ...Option) (*Byte, error) {
+	return NewByteOf[byte](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewByteOf wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewByte].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewByteOf[T ~byte](a arrow.Array, opts ...Option) (*ByteOf[T], error) {
+	r := &ByteOf[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *ByteOf[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *ByteOf[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = ByteOf[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewByteOf with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *ByteOf[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *ByteOf[T]) Slice(i, j int) *ByteOf[T] {
+	r := &ByteOf[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *ByteOf[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := ByteOf[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := byteBuffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Uint8)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := byteBuffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) byte {
-				return byte(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for byte", dt.ValueType.String())
 		}
+	}
 
+	return nil
+}
+
+// byteBuffer creates the [buffer] for the arrays that can be converted to byte.
+func byteBuffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype byte", a.String())
+		return buffer{}, false
 	}
 }
 
 // Int8 provides convenient access to [arrow.Array]'s element as int8
-type Int8 struct {
+type Int8 = Int8Of[int8]
+
+// Int8Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is int8.
+type Int8Of[T ~int8] struct {
 	arrowArray
 
-	direct  *array.Int8
-	getFunc func(int) int8
+	direct *array.Int8
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Int8)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Int8].
-func (a *Int8) IsDirect() bool {
+func (a *Int8Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as int8
-func (a *Int8) Value(i int) int8 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type int8")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Int8Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Int8"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewInt8 wraps the provided [arrow.Array].
-func NewInt8(a arrow.Array) (*Int8, error) {
-	if direct, ok := a.(*array.Int8); ok {
-		return &Int8{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Int8Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.i8[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Int8{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Int8Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Int8Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Int8Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Int8Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Int8Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type int8")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Int8Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type int8")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Int8Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int8 {
-			return int8(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Int8Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Int8Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Int8Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Int8Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Int8Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Int8Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type int8")
+	}
+}
 
-			return r, nil
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Int8Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+	return dst
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to int8 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Int8Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-			return r, nil
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Int8Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt8:
+			return reinterpretSlice[T](a.values.i8), true
+		case kindUint8:
+			return reinterpretSlice[T](a.values.u8), true
+		}
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
-
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Int8Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Int8Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Int8Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Int8Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewInt8 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as int8,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewInt8(a arrow.Array, opts ...Option) (*Int8, error) {
+	return NewInt8Of[int8](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewInt8Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewInt8].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewInt8Of[T ~int8](a arrow.Array, opts ...Option) (*Int8Of[T], error) {
+	r := &Int8Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Int8Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Int8Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Int8Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewInt8Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Int8Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Int8Of[T]) Slice(i, j int) *Int8Of[T] {
+	r := &Int8Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Int8Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Int8Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := int8Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Int8)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := int8Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int8 {
-				return int8(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for int8", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// int8Buffer creates the [buffer] for the arrays that can be converted to int8.
+func int8Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype int8", a.String())
+		return buffer{}, false
 	}
 }
 
 // Int16 provides convenient access to [arrow.Array]'s element as int16
-type Int16 struct {
+type Int16 = Int16Of[int16]
+
+// Int16Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is int16.
+type Int16Of[T ~int16] struct {
 	arrowArray
 
-	direct  *array.Int16
-	getFunc func(int) int16
+	direct *array.Int16
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Int16)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Int16].
-func (a *Int16) IsDirect() bool {
+func (a *Int16Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as int16
-func (a *Int16) Value(i int) int16 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type int16")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Int16Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Int16"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewInt16 wraps the provided [arrow.Array].
-func NewInt16(a arrow.Array) (*Int16, error) {
-	if direct, ok := a.(*array.Int16); ok {
-		return &Int16{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Int16Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.i16[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Int16{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Int16Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Int16Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Int16Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Int16Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Int16Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type int16")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Int16Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type int16")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Int16Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int16 {
-			return int16(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Int16Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Int16Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Int16Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Int16Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Int16Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Int16Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type int16")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Int16Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to int16 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Int16Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Int16Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt16:
+			return reinterpretSlice[T](a.values.i16), true
+		case kindUint16:
+			return reinterpretSlice[T](a.values.u16), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Int16Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Int16Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Int16Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Int16Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewInt16 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as int16,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewInt16(a arrow.Array, opts ...Option) (*Int16, error) {
+	return NewInt16Of[int16](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewInt16Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewInt16].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewInt16Of[T ~int16](a arrow.Array, opts ...Option) (*Int16Of[T], error) {
+	r := &Int16Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Int16Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Int16Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Int16Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewInt16Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Int16Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Int16Of[T]) Slice(i, j int) *Int16Of[T] {
+	r := &Int16Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Int16Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Int16Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := int16Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Int16)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := int16Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int16 {
-				return int16(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for int16", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// int16Buffer creates the [buffer] for the arrays that can be converted to int16.
+func int16Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype int16", a.String())
+		return buffer{}, false
 	}
 }
 
 // Int32 provides convenient access to [arrow.Array]'s element as int32
-type Int32 struct {
+type Int32 = Int32Of[int32]
+
+// Int32Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is int32.
+type Int32Of[T ~int32] struct {
 	arrowArray
 
-	direct  *array.Int32
-	getFunc func(int) int32
+	direct *array.Int32
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Int32)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Int32].
-func (a *Int32) IsDirect() bool {
+func (a *Int32Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as int32
-func (a *Int32) Value(i int) int32 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type int32")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Int32Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Int32"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewInt32 wraps the provided [arrow.Array].
-func NewInt32(a arrow.Array) (*Int32, error) {
-	if direct, ok := a.(*array.Int32); ok {
-		return &Int32{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Int32Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.i32[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Int32{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Int32Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Int32Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Int32Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Int32Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Int32Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type int32")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Int32Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type int32")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Int32Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int32 {
-			return int32(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Int32Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Int32Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Int32Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Int32Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Int32Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Int32Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type int32")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Int32Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to int32 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Int32Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Int32Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt32:
+			return reinterpretSlice[T](a.values.i32), true
+		case kindUint32:
+			return reinterpretSlice[T](a.values.u32), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Int32Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Int32Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Int32Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Int32Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewInt32 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as int32,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewInt32(a arrow.Array, opts ...Option) (*Int32, error) {
+	return NewInt32Of[int32](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewInt32Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewInt32].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewInt32Of[T ~int32](a arrow.Array, opts ...Option) (*Int32Of[T], error) {
+	r := &Int32Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Int32Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Int32Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Int32Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewInt32Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Int32Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Int32Of[T]) Slice(i, j int) *Int32Of[T] {
+	r := &Int32Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Int32Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Int32Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := int32Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Int32)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := int32Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int32 {
-				return int32(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for int32", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// int32Buffer creates the [buffer] for the arrays that can be converted to int32.
+func int32Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype int32", a.String())
+		return buffer{}, false
 	}
 }
 
 // Int64 provides convenient access to [arrow.Array]'s element as int64
-type Int64 struct {
+type Int64 = Int64Of[int64]
+
+// Int64Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is int64.
+type Int64Of[T ~int64] struct {
 	arrowArray
 
-	direct  *array.Int64
-	getFunc func(int) int64
+	direct *array.Int64
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Int64)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Int64].
-func (a *Int64) IsDirect() bool {
+func (a *Int64Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as int64
-func (a *Int64) Value(i int) int64 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type int64")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Int64Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Int64"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewInt64 wraps the provided [arrow.Array].
-func NewInt64(a arrow.Array) (*Int64, error) {
-	if direct, ok := a.(*array.Int64); ok {
-		return &Int64{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Int64Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.i64[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Int64{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Int64Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Int64Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Int64Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Int64Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Int64Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type int64")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Int64Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type int64")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Int64Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) int64 {
-			return int64(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Int64Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Int64Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Int64Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Int64Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Int64Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Int64Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type int64")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Int64Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to int64 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Int64Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Int64Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt64:
+			return reinterpretSlice[T](a.values.i64), true
+		case kindUint64:
+			return reinterpretSlice[T](a.values.u64), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Int64Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Int64Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Int64Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Int64Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewInt64 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as int64,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewInt64(a arrow.Array, opts ...Option) (*Int64, error) {
+	return NewInt64Of[int64](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewInt64Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewInt64].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewInt64Of[T ~int64](a arrow.Array, opts ...Option) (*Int64Of[T], error) {
+	r := &Int64Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Int64Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Int64Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Int64Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewInt64Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Int64Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Int64Of[T]) Slice(i, j int) *Int64Of[T] {
+	r := &Int64Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Int64Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Int64Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := int64Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Int64)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := int64Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) int64 {
-				return int64(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for int64", dt.ValueType.String())
 		}
+	}
 
+	return nil
+}
+
+// int64Buffer creates the [buffer] for the arrays that can be converted to int64.
+func int64Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype int64", a.String())
+		return buffer{}, false
 	}
 }
 
 // Uint8 provides convenient access to [arrow.Array]'s element as uint8
-type Uint8 struct {
+type Uint8 = Uint8Of[uint8]
+
+// Uint8Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is uint8.
+type Uint8Of[T ~uint8] struct {
 	arrowArray
 
-	direct  *array.Uint8
-	getFunc func(int) uint8
+	direct *array.Uint8
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Uint8)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Uint8].
-func (a *Uint8) IsDirect() bool {
+func (a *Uint8Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as uint8
-func (a *Uint8) Value(i int) uint8 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type uint8")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Uint8Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Uint8"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewUint8 wraps the provided [arrow.Array].
-func NewUint8(a arrow.Array) (*Uint8, error) {
-	if direct, ok := a.(*array.Uint8); ok {
-		return &Uint8{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Uint8Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.u8[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Uint8{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Uint8Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Uint8Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Uint8Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Uint8Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Uint8Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type uint8")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Uint8Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type uint8")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Uint8Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint8 {
-			return uint8(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Uint8Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Uint8Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Uint8Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Uint8Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Uint8Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Uint8Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type uint8")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Uint8Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to uint8 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Uint8Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Uint8Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt8:
+			return reinterpretSlice[T](a.values.i8), true
+		case kindUint8:
+			return reinterpretSlice[T](a.values.u8), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Uint8Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Uint8Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Uint8Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Uint8Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewUint8 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as uint8,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewUint8(a arrow.Array, opts ...Option) (*Uint8, error) {
+	return NewUint8Of[uint8](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewUint8Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewUint8].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewUint8Of[T ~uint8](a arrow.Array, opts ...Option) (*Uint8Of[T], error) {
+	r := &Uint8Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Uint8Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Uint8Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Uint8Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewUint8Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Uint8Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Uint8Of[T]) Slice(i, j int) *Uint8Of[T] {
+	r := &Uint8Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Uint8Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Uint8Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := uint8Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Uint8)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := uint8Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint8 {
-				return uint8(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for uint8", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// uint8Buffer creates the [buffer] for the arrays that can be converted to uint8.
+func uint8Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype uint8", a.String())
+		return buffer{}, false
 	}
 }
 
 // Uint16 provides convenient access to [arrow.Array]'s element as uint16
-type Uint16 struct {
+type Uint16 = Uint16Of[uint16]
+
+// Uint16Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is uint16.
+type Uint16Of[T ~uint16] struct {
 	arrowArray
 
-	direct  *array.Uint16
-	getFunc func(int) uint16
+	direct *array.Uint16
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Uint16)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Uint16].
-func (a *Uint16) IsDirect() bool {
+func (a *Uint16Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as uint16
-func (a *Uint16) Value(i int) uint16 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type uint16")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Uint16Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Uint16"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewUint16 wraps the provided [arrow.Array].
-func NewUint16(a arrow.Array) (*Uint16, error) {
-	if direct, ok := a.(*array.Uint16); ok {
-		return &Uint16{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Uint16Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.u16[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Uint16{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Uint16Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Uint16Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Uint16Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Uint16Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Uint16Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type uint16")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Uint16Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type uint16")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Uint16Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint16 {
-			return uint16(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Uint16Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Uint16Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Uint16Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Uint16Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Uint16Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Uint16Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type uint16")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Uint16Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to uint16 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Uint16Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Uint16Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt16:
+			return reinterpretSlice[T](a.values.i16), true
+		case kindUint16:
+			return reinterpretSlice[T](a.values.u16), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Uint16Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Uint16Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Uint16Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Uint16Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewUint16 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as uint16,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewUint16(a arrow.Array, opts ...Option) (*Uint16, error) {
+	return NewUint16Of[uint16](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewUint16Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewUint16].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewUint16Of[T ~uint16](a arrow.Array, opts ...Option) (*Uint16Of[T], error) {
+	r := &Uint16Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Uint16Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Uint16Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Uint16Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewUint16Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Uint16Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Uint16Of[T]) Slice(i, j int) *Uint16Of[T] {
+	r := &Uint16Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Uint16Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Uint16Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := uint16Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Uint16)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := uint16Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint16 {
-				return uint16(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for uint16", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// uint16Buffer creates the [buffer] for the arrays that can be converted to uint16.
+func uint16Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype uint16", a.String())
+		return buffer{}, false
 	}
 }
 
 // Uint32 provides convenient access to [arrow.Array]'s element as uint32
-type Uint32 struct {
+type Uint32 = Uint32Of[uint32]
+
+// Uint32Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is uint32.
+type Uint32Of[T ~uint32] struct {
 	arrowArray
 
-	direct  *array.Uint32
-	getFunc func(int) uint32
+	direct *array.Uint32
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Uint32)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Uint32].
-func (a *Uint32) IsDirect() bool {
+func (a *Uint32Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as uint32
-func (a *Uint32) Value(i int) uint32 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type uint32")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Uint32Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Uint32"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewUint32 wraps the provided [arrow.Array].
-func NewUint32(a arrow.Array) (*Uint32, error) {
-	if direct, ok := a.(*array.Uint32); ok {
-		return &Uint32{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Uint32Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.u32[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Uint32{}
-
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
-
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+	return a.value(i)
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Uint32Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Uint32Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Uint32Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Uint32Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Uint32Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type uint32")
+	}
+}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Uint32Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type uint32")
+	}
+}
 
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Uint32Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint32 {
-			return uint32(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return nil
+}
 
-			return r, nil
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Uint32Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Uint32Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return i
+}
 
-			return r, nil
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Uint32Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Uint32Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.value(j)
+}
 
-			return r, nil
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Uint32Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Uint32Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			return r, nil
+		return n
+	}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type uint32")
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Uint32Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			return r, nil
+	return dst
+}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to uint32 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Uint32Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			return r, nil
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Uint32Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt32:
+			return reinterpretSlice[T](a.values.i32), true
+		case kindUint32:
+			return reinterpretSlice[T](a.values.u32), true
+		}
+	}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
-			}
+	return nil, false
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Uint32Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Uint32Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
-
-			return r, nil
-
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Uint32Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Uint32Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewUint32 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as uint32,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewUint32(a arrow.Array, opts ...Option) (*Uint32, error) {
+	return NewUint32Of[uint32](a, opts...)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// NewUint32Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewUint32].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewUint32Of[T ~uint32](a arrow.Array, opts ...Option) (*Uint32Of[T], error) {
+	r := &Uint32Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Uint32Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Uint32Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Uint32Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewUint32Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Uint32Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Uint32Of[T]) Slice(i, j int) *Uint32Of[T] {
+	r := &Uint32Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Uint32Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	r := Uint32Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint32 {
-				return uint32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := uint32Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Uint32)
+		*a = r
+
+		return nil
+	}
 
-			return r, nil
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := uint32Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for uint32", dt.ValueType.String())
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
+			}
 		}
+	}
+
+	return nil
+}
 
+// uint32Buffer creates the [buffer] for the arrays that can be converted to uint32.
+func uint32Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype uint32", a.String())
+		return buffer{}, false
 	}
 }
 
 // Uint64 provides convenient access to [arrow.Array]'s element as uint64
-type Uint64 struct {
+type Uint64 = Uint64Of[uint64]
+
+// Uint64Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is uint64.
+type Uint64Of[T ~uint64] struct {
 	arrowArray
 
-	direct  *array.Uint64
-	getFunc func(int) uint64
+	direct *array.Uint64
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Uint64)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Uint64].
-func (a *Uint64) IsDirect() bool {
+func (a *Uint64Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as uint64
-func (a *Uint64) Value(i int) uint64 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type uint64")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Uint64Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Uint64"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewUint64 wraps the provided [arrow.Array].
-func NewUint64(a arrow.Array) (*Uint64, error) {
-	if direct, ok := a.(*array.Uint64); ok {
-		return &Uint64{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Uint64Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.u64[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Uint64{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Uint64Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Uint64Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Uint64Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Uint64Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Uint64Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(decimal128Int(a.values.d128[i], a.values.scale))
+	case kindDecimal256:
+		return T(decimal256Int(a.values.d256[i], a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type uint64")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Uint64Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type uint64")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Uint64Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) uint64 {
-			return uint64(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Uint64Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Uint64Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Uint64Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Uint64Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Uint64Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Uint64Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type uint64")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Uint64Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to uint64 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Uint64Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Uint64Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindInt64:
+			return reinterpretSlice[T](a.values.i64), true
+		case kindUint64:
+			return reinterpretSlice[T](a.values.u64), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Uint64Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Uint64Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Uint64Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Uint64Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewUint64 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as uint64,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewUint64(a arrow.Array, opts ...Option) (*Uint64, error) {
+	return NewUint64Of[uint64](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewUint64Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewUint64].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewUint64Of[T ~uint64](a arrow.Array, opts ...Option) (*Uint64Of[T], error) {
+	r := &Uint64Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Uint64Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Uint64Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Uint64Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewUint64Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Uint64Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Uint64Of[T]) Slice(i, j int) *Uint64Of[T] {
+	r := &Uint64Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Uint64Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Uint64Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := uint64Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Uint64)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := uint64Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) uint64 {
-				return uint64(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for uint64", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// uint64Buffer creates the [buffer] for the arrays that can be converted to uint64.
+func uint64Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype uint64", a.String())
+		return buffer{}, false
 	}
 }
 
 // Float32 provides convenient access to [arrow.Array]'s element as float32
-type Float32 struct {
+type Float32 = Float32Of[float32]
+
+// Float32Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is float32.
+type Float32Of[T ~float32] struct {
 	arrowArray
 
-	direct  *array.Float32
-	getFunc func(int) float32
+	direct *array.Float32
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Float32)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Float32].
-func (a *Float32) IsDirect() bool {
+func (a *Float32Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as float32
-func (a *Float32) Value(i int) float32 {
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Float32Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Float32"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
+	}
+}
+
+// Value retrieves the element at index i as T
+func (a *Float32Of[T]) Value(i int) T {
 	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type float32")
+		return T(a.values.f32[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
+
+	return a.value(i)
 }
 
-// NewFloat32 wraps the provided [arrow.Array].
-func NewFloat32(a arrow.Array) (*Float32, error) {
-	if direct, ok := a.(*array.Float32); ok {
-		return &Float32{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Float32Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
 	}
 
-	r := &Float32{}
+	return a.Value(i), true
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Float32Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Float32Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Float32Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Float32Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(a.values.d128[i].ToFloat64(a.values.scale))
+	case kindDecimal256:
+		return T(a.values.d256[i].ToFloat64(a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type float32")
+	}
+}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Float32Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type float32")
+	}
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Float32Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float32 {
-			return float32(v.Value(i))
-		}
-		return r, nil
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Float32Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Float32Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return i
+}
 
-			return r, nil
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Float32Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Float32Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.value(j)
+}
 
-			return r, nil
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Float32Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Float32Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			return r, nil
+		return n
+	}
 
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type float32")
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Float32Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			return r, nil
+	return dst
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to float32 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Float32Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			return r, nil
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Float32Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindFloat32:
+			return reinterpretSlice[T](a.values.f32), true
+		}
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return nil, false
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Float32Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			return r, nil
-
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Float32Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
 			}
-
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Float32Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Float32Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewFloat32 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as float32,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewFloat32(a arrow.Array, opts ...Option) (*Float32, error) {
+	return NewFloat32Of[float32](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewFloat32Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewFloat32].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewFloat32Of[T ~float32](a arrow.Array, opts ...Option) (*Float32Of[T], error) {
+	r := &Float32Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Float32Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Float32Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Float32Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewFloat32Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Float32Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Float32Of[T]) Slice(i, j int) *Float32Of[T] {
+	r := &Float32Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Float32Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Float32Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := float32Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Float32)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := float32Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float32 {
-				return float32(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for float32", dt.ValueType.String())
 		}
+	}
 
+	return nil
+}
+
+// float32Buffer creates the [buffer] for the arrays that can be converted to float32.
+func float32Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype float32", a.String())
+		return buffer{}, false
 	}
 }
 
 // Float64 provides convenient access to [arrow.Array]'s element as float64
-type Float64 struct {
+type Float64 = Float64Of[float64]
+
+// Float64Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is float64.
+type Float64Of[T ~float64] struct {
 	arrowArray
 
-	direct  *array.Float64
-	getFunc func(int) float64
+	direct *array.Float64
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*Float64)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.Float64].
-func (a *Float64) IsDirect() bool {
+func (a *Float64Of[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as float64
-func (a *Float64) Value(i int) float64 {
-	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
-		panic("uninitialized accessor for go type float64")
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *Float64Of[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.Float64"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
 	}
 }
 
-// NewFloat64 wraps the provided [arrow.Array].
-func NewFloat64(a arrow.Array) (*Float64, error) {
-	if direct, ok := a.(*array.Float64); ok {
-		return &Float64{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// Value retrieves the element at index i as T
+func (a *Float64Of[T]) Value(i int) T {
+	if a.direct != nil {
+		return T(a.values.f64[i])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
 	}
 
-	r := &Float64{}
+	return a.value(i)
+}
 
-	switch v := a.(type) {
-	case *array.Int8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *Float64Of[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
 
-	case *array.Int16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+	return a.Value(i), true
+}
 
-	case *array.Int32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Float64Of[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
 
-	case *array.Int64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+	return a.values.kind != kindNull
+}
 
-	case *array.Uint8:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Float64Of[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
 
-	case *array.Uint16:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// NullN returns the number of null elements, see IsValid.
+func (a *Float64Of[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
 
-	case *array.Uint32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+	return a.Array.NullN()
+}
 
-	case *array.Uint64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// value retrieves the element at index i of the values.
+func (a *Float64Of[T]) value(i int) T {
+	switch a.values.kind {
+	case kindInt8:
+		return T(a.values.i8[i])
+	case kindInt16:
+		return T(a.values.i16[i])
+	case kindInt32:
+		return T(a.values.i32[i])
+	case kindInt64:
+		return T(a.values.i64[i])
+	case kindUint8:
+		return T(a.values.u8[i])
+	case kindUint16:
+		return T(a.values.u16[i])
+	case kindUint32:
+		return T(a.values.u32[i])
+	case kindUint64:
+		return T(a.values.u64[i])
+	case kindFloat32:
+		return T(a.values.f32[i])
+	case kindFloat64:
+		return T(a.values.f64[i])
+	case kindBoolean:
+		return fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))
+	case kindDecimal128:
+		return T(a.values.d128[i].ToFloat64(a.values.scale))
+	case kindDecimal256:
+		return T(a.values.d256[i].ToFloat64(a.values.scale))
+	case kindNull:
+		return *new(T)
+	default:
+		panic("uninitialized accessor for go type float64")
+	}
+}
 
-	case *array.Timestamp:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *Float64Of[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindInt8:
+		return intFits[T](int64(a.values.i8[i]))
+	case kindInt16:
+		return intFits[T](int64(a.values.i16[i]))
+	case kindInt32:
+		return intFits[T](int64(a.values.i32[i]))
+	case kindInt64:
+		return intFits[T](int64(a.values.i64[i]))
+	case kindUint8:
+		return uintFits[T](uint64(a.values.u8[i]))
+	case kindUint16:
+		return uintFits[T](uint64(a.values.u16[i]))
+	case kindUint32:
+		return uintFits[T](uint64(a.values.u32[i]))
+	case kindUint64:
+		return uintFits[T](uint64(a.values.u64[i]))
+	case kindFloat32:
+		return floatFits[T](float64(a.values.f32[i]))
+	case kindFloat64:
+		return floatFits[T](float64(a.values.f64[i]))
+	case kindBoolean:
+		return true
+	case kindDecimal128:
+		return decimal128Fits[T](a.values.d128[i], a.values.scale)
+	case kindDecimal256:
+		return decimal256Fits[T](a.values.d256[i], a.values.scale)
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type float64")
+	}
+}
 
-	case *array.Duration:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *Float64Of[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	case *array.Float32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
 		}
-		return r, nil
-
-	case *array.Float64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
 		}
-		return r, nil
-
-	case *array.Date32:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Date64:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) float64 {
-			return float64(v.Value(i))
-		}
-		return r, nil
+	return nil
+}
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
-		}
-		switch dt.ValueType.ID() {
-		case arrow.INT8:
-			dictvalues, ok := v.Dictionary().(*array.Int8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int8", v.Dictionary().DataType().String())
-			}
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Float64Of[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *Float64Of[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
 
-			return r, nil
+	return i
+}
 
-		case arrow.INT16:
-			dictvalues, ok := v.Dictionary().(*array.Int16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int16", v.Dictionary().DataType().String())
-			}
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *Float64Of[T]) DictionaryLen() int {
+	return a.values.len()
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *Float64Of[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
 
-			return r, nil
+	return a.value(j)
+}
 
-		case arrow.INT32:
-			dictvalues, ok := v.Dictionary().(*array.Int32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int32", v.Dictionary().DataType().String())
-			}
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *Float64Of[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *Float64Of[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
 			}
+		}
 
-			return r, nil
-
-		case arrow.INT64:
-			dictvalues, ok := v.Dictionary().(*array.Int64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Int64", v.Dictionary().DataType().String())
-			}
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
+		}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	}
 
-			return r, nil
+	switch a.values.kind {
+	case kindInt8:
+		return convertSlice(dst, a.values.i8[start:])
+	case kindInt16:
+		return convertSlice(dst, a.values.i16[start:])
+	case kindInt32:
+		return convertSlice(dst, a.values.i32[start:])
+	case kindInt64:
+		return convertSlice(dst, a.values.i64[start:])
+	case kindUint8:
+		return convertSlice(dst, a.values.u8[start:])
+	case kindUint16:
+		return convertSlice(dst, a.values.u16[start:])
+	case kindUint32:
+		return convertSlice(dst, a.values.u32[start:])
+	case kindUint64:
+		return convertSlice(dst, a.values.u64[start:])
+	case kindFloat32:
+		return convertSlice(dst, a.values.f32[start:])
+	case kindFloat64:
+		return convertSlice(dst, a.values.f64[start:])
+	case kindBoolean:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal128:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindDecimal256:
+		for i := range dst {
+			dst[i] = a.value(start + i)
+		}
+
+		return n
+	case kindNull:
+		clear(dst)
+
+		return n
+	default:
+		panic("uninitialized accessor for go type float64")
+	}
+}
 
-		case arrow.UINT8:
-			dictvalues, ok := v.Dictionary().(*array.Uint8)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint8", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *Float64Of[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
 
-			return r, nil
+// Values returns all the elements as a slice.
+//
+// When the array is direct, or the conversion to float64 keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+func (a *Float64Of[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-		case arrow.UINT16:
-			dictvalues, ok := v.Dictionary().(*array.Uint16)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint16", v.Dictionary().DataType().String())
-			}
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *Float64Of[T]) sharedValues() ([]T, bool) {
+	if a.indices.kind == kindUnknown {
+		switch a.values.kind {
+		case kindFloat64:
+			return reinterpretSlice[T](a.values.f64), true
+		}
+	}
 
-			return r, nil
+	return nil, false
+}
 
-		case arrow.UINT32:
-			dictvalues, ok := v.Dictionary().(*array.Uint32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint32", v.Dictionary().DataType().String())
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *Float64Of[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
-
-		case arrow.UINT64:
-			dictvalues, ok := v.Dictionary().(*array.Uint64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Uint64", v.Dictionary().DataType().String())
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *Float64Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *Float64Of[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *Float64Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
 
-		case arrow.TIMESTAMP:
-			dictvalues, ok := v.Dictionary().(*array.Timestamp)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Timestamp", v.Dictionary().DataType().String())
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
 			}
-
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// NewFloat64 wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as float64,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewFloat64(a arrow.Array, opts ...Option) (*Float64, error) {
+	return NewFloat64Of[float64](a, opts...)
+}
 
-		case arrow.DURATION:
-			dictvalues, ok := v.Dictionary().(*array.Duration)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Duration", v.Dictionary().DataType().String())
-			}
+// NewFloat64Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewFloat64].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewFloat64Of[T ~float64](a arrow.Array, opts ...Option) (*Float64Of[T], error) {
+	r := &Float64Of[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *Float64Of[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
 
-		case arrow.FLOAT32:
-			dictvalues, ok := v.Dictionary().(*array.Float32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float32", v.Dictionary().DataType().String())
-			}
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Float64Of[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Float64Of[T]{}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+// Reset points the accessor to arr, as if the accessor was created by NewFloat64Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *Float64Of[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
 
-			return r, nil
+	return nil
+}
 
-		case arrow.FLOAT64:
-			dictvalues, ok := v.Dictionary().(*array.Float64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Float64", v.Dictionary().DataType().String())
-			}
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *Float64Of[T]) Slice(i, j int) *Float64Of[T] {
+	r := &Float64Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return r
+}
 
-			return r, nil
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *Float64Of[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
+		}
+	}
 
-		case arrow.DATE32:
-			dictvalues, ok := v.Dictionary().(*array.Date32)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date32", v.Dictionary().DataType().String())
-			}
+	r := Float64Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := float64Buffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.Float64)
+		*a = r
 
-			return r, nil
+		return nil
+	}
 
-		case arrow.DATE64:
-			dictvalues, ok := v.Dictionary().(*array.Date64)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Date64", v.Dictionary().DataType().String())
-			}
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := float64Buffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) float64 {
-				return float64(dictvalues.Value(v.GetValueIndex(i)))
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
 			}
-
-			return r, nil
-
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for float64", dt.ValueType.String())
 		}
+	}
+
+	return nil
+}
 
+// float64Buffer creates the [buffer] for the arrays that can be converted to float64.
+func float64Buffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.Int8:
+		return buffer{kind: kindInt8, i8: v.Int8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindInt16, i16: v.Int16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindInt32, i32: v.Int32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindInt64, i64: v.Int64Values()}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	case *array.Timestamp:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.TimestampValues())}, true
+	case *array.Duration:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.DurationValues())}, true
+	case *array.Float32:
+		return buffer{kind: kindFloat32, f32: v.Float32Values()}, true
+	case *array.Float64:
+		return buffer{kind: kindFloat64, f64: v.Float64Values()}, true
+	case *array.Date32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Date32Values())}, true
+	case *array.Date64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Date64Values())}, true
+	case *array.Time32:
+		return buffer{kind: kindInt32, i32: reinterpretSlice[int32](v.Time32Values())}, true
+	case *array.Time64:
+		return buffer{kind: kindInt64, i64: reinterpretSlice[int64](v.Time64Values())}, true
+	case *array.Boolean:
+		return booleanBuffer(v), true
+	case *array.Decimal128:
+		return decimal128Buffer(v), true
+	case *array.Decimal256:
+		return decimal256Buffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype float64", a.String())
+		return buffer{}, false
 	}
 }
 
 // String provides convenient access to [arrow.Array]'s element as string
-type String struct {
+type String = StringOf[string]
+
+// StringOf provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is string.
+type StringOf[T ~string] struct {
 	arrowArray
 
-	direct  *array.String
-	getFunc func(int) string
+	direct *array.String
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+	decoded []T
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+	dictionary arrow.ArrayData
+
+	refCount int64
+
+	opts options
 }
 
 var _ arrow.Array = (*String)(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.String].
-func (a *String) IsDirect() bool {
+func (a *StringOf[T]) IsDirect() bool {
 	return a.direct != nil
 }
 
-// Value retrieves the element at index i as string
-func (a *String) Value(i int) string {
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *StringOf[T]) Explain() string {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	switch {
+	case a.direct != nil:
+		return c.String() + ", read directly from array.String"
+	case a.decoded != nil:
+		return c.String() + ", decoded once into a slice"
+	default:
+		return c.String() + ", converted on every read"
+	}
+}
+
+// Value retrieves the element at index i as T
+func (a *StringOf[T]) Value(i int) T {
 	if a.direct != nil {
-		return a.direct.Value(i)
-	} else if a.getFunc != nil {
-		return a.getFunc(i)
-	} else {
+		return T(a.values.data[a.values.offsets[i]:a.values.offsets[i+1]])
+	}
+	if a.indices.kind != kindUnknown {
+		return a.DictValue(a.indices.index(i))
+	}
+
+	return a.value(i)
+}
+
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *StringOf[T]) ValueOk(i int) (T, bool) {
+	if !a.IsValid(i) {
+		var zero T
+		return zero, false
+	}
+
+	return a.Value(i), true
+}
+
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *StringOf[T]) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
+
+	return a.values.kind != kindNull
+}
+
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *StringOf[T]) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
+
+// NullN returns the number of null elements, see IsValid.
+func (a *StringOf[T]) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
+
+	return a.Array.NullN()
+}
+
+// value retrieves the element at index i of the values.
+func (a *StringOf[T]) value(i int) T {
+	switch a.values.kind {
+	case kindBinary:
+		return T(a.values.data[a.values.offsets[i]:a.values.offsets[i+1]])
+	case kindLargeBinary:
+		return T(a.values.data[a.values.largeOffsets[i]:a.values.largeOffsets[i+1]])
+	case kindNull:
+		return *new(T)
+	default:
 		panic("uninitialized accessor for go type string")
 	}
 }
 
-// NewString wraps the provided [arrow.Array].
-func NewString(a arrow.Array) (*String, error) {
-	if direct, ok := a.(*array.String); ok {
-		return &String{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *StringOf[T]) fits(i int) bool {
+	switch a.values.kind {
+	case kindBinary:
+		return true
+	case kindLargeBinary:
+		return true
+	case kindNull:
+		return true
+	default:
+		panic("uninitialized accessor for go type string")
 	}
+}
 
-	r := &String{}
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *StringOf[T]) Validate() error {
+	c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+	if c.Kind != ConversionNarrowing {
+		return nil
+	}
 
-	switch v := a.(type) {
-	case *array.String:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) string {
-			return string(v.Value(i))
+	var dictionaryFits []bool
+	if a.indices.kind != kindUnknown {
+		dictionaryFits = make([]bool, a.values.len())
+		for j := range dictionaryFits {
+			dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
+		}
+	}
+	for i := range a.Len() {
+		if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+			continue
+		}
+		if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+			continue
 		}
-		return r, nil
+		return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+	}
 
-	case *array.Binary:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) string {
-			return string(v.Value(i))
+	return nil
+}
+
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *StringOf[T]) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
+
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *StringOf[T]) Index(i int) int {
+	if a.indices.kind != kindUnknown {
+		return a.indices.index(i)
+	}
+
+	return i
+}
+
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *StringOf[T]) DictionaryLen() int {
+	return a.values.len()
+}
+
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *StringOf[T]) DictValue(j int) T {
+	if a.decoded != nil {
+		return a.decoded[j]
+	}
+
+	return a.value(j)
+}
+
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *StringOf[T]) CopyTo(dst []T) int {
+	return a.copyFrom(dst, 0)
+}
+
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *StringOf[T]) copyFrom(dst []T, start int) int {
+	n := min(len(dst), a.Len()-start)
+	dst = dst[:n]
+	if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+		// the indices of null slots are undefined and must not be looked up.
+		for i := range dst {
+			if a.Array.IsNull(start + i) {
+				dst[i] = *new(T)
+			} else {
+				dst[i] = a.DictValue(a.indices.index(start + i))
+			}
 		}
-		return r, nil
 
-	case *array.LargeString:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) string {
-			return string(v.Value(i))
+		return n
+	}
+	if a.indices.kind != kindUnknown && a.decoded != nil {
+		switch a.indices.kind {
+		case kindUint8:
+			return gather(dst, a.indices.u8[start:], a.decoded)
+		case kindUint16:
+			return gather(dst, a.indices.u16[start:], a.decoded)
+		case kindUint32:
+			return gather(dst, a.indices.u32[start:], a.decoded)
+		case kindUint64:
+			return gather(dst, a.indices.u64[start:], a.decoded)
+		}
+	}
+	if a.indices.kind != kindUnknown {
+		for i := range dst {
+			dst[i] = a.value(a.indices.index(start + i))
 		}
-		return r, nil
 
-	case *array.LargeBinary:
-		r.arrowArray.Array = a
-		r.getFunc = func(i int) string {
-			return string(v.Value(i))
+		return n
+	}
+
+	switch a.values.kind {
+	case kindBinary:
+		for i := range dst {
+			dst[i] = a.value(start + i)
 		}
-		return r, nil
 
-	case *array.Dictionary:
-		dt, ok := v.DataType().(*arrow.DictionaryType)
-		if !ok {
-			return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
+		return n
+	case kindLargeBinary:
+		for i := range dst {
+			dst[i] = a.value(start + i)
 		}
-		switch dt.ValueType.ID() {
-		case arrow.STRING:
-			dictvalues, ok := v.Dictionary().(*array.String)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type String", v.Dictionary().DataType().String())
-			}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) string {
-				return string(dictvalues.Value(v.GetValueIndex(i)))
-			}
+		return n
+	case kindNull:
+		clear(dst)
 
-			return r, nil
+		return n
+	default:
+		panic("uninitialized accessor for go type string")
+	}
+}
 
-		case arrow.BINARY:
-			dictvalues, ok := v.Dictionary().(*array.Binary)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type Binary", v.Dictionary().DataType().String())
-			}
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *StringOf[T]) AppendValues(dst []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+	a.CopyTo(dst[n:])
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) string {
-				return string(dictvalues.Value(v.GetValueIndex(i)))
-			}
+	return dst
+}
+
+// Values returns all the elements as a slice.
+// The returned slice is always newly allocated.
+func (a *StringOf[T]) Values() []T {
+	if values, ok := a.sharedValues(); ok {
+		return values
+	}
 
-			return r, nil
+	return a.AppendValues(make([]T, 0, a.Len()))
+}
 
-		case arrow.LARGE_STRING:
-			dictvalues, ok := v.Dictionary().(*array.LargeString)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type LargeString", v.Dictionary().DataType().String())
-			}
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *StringOf[T]) sharedValues() ([]T, bool) {
+	return nil, false
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) string {
-				return string(dictvalues.Value(v.GetValueIndex(i)))
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *StringOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			var v T
+			// the indices of null slots are undefined and must not be looked up.
+			if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+				v = a.Value(i)
+			}
+			if !yield(i, v) {
+				return
 			}
+		}
+	}
+}
 
-			return r, nil
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *StringOf[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+	return func(yield func(int, Nullable[T]) bool) {
+		for i := range a.Len() {
+			var v Nullable[T]
+			if a.IsValid(i) {
+				v = Nullable[T]{Value: a.Value(i), Valid: true}
+			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
 
-		case arrow.LARGE_BINARY:
-			dictvalues, ok := v.Dictionary().(*array.LargeBinary)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type LargeBinary", v.Dictionary().DataType().String())
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *StringOf[T]) Valid() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range a.Len() {
+			if a.IsValid(i) && !yield(i, a.Value(i)) {
+				return
 			}
+		}
+	}
+}
 
-			r.arrowArray.Array = a
-			r.getFunc = func(i int) string {
-				return string(dictvalues.Value(v.GetValueIndex(i)))
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *StringOf[T]) Chunks(size int) iter.Seq2[int, []T] {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d is not positive", size))
+	}
+
+	return func(yield func(int, []T) bool) {
+		n := a.Len()
+		values, shared := a.sharedValues()
+		if !shared {
+			values = make([]T, min(size, n))
+		}
+		for start := 0; start < n; start += size {
+			var chunk []T
+			if shared {
+				chunk = values[start:min(start+size, n)]
+			} else {
+				chunk = values[:a.copyFrom(values, start)]
+			}
+			if !yield(start, chunk) {
+				return
 			}
+		}
+	}
+}
+
+// NewString wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as string,
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func NewString(a arrow.Array, opts ...Option) (*String, error) {
+	return NewStringOf[string](a, opts...)
+}
+
+// NewStringOf wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [NewString].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func NewStringOf[T ~string](a arrow.Array, opts ...Option) (*StringOf[T], error) {
+	r := &StringOf[T]{opts: newOptions(opts), refCount: 1}
+	if err := r.bind(a); err != nil {
+		return nil, err
+	}
+	a.Retain()
+
+	return r, nil
+}
 
-			return r, nil
+// Retain increases the reference count of the accessor by 1.
+func (a *StringOf[T]) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
+
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *StringOf[T]) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = StringOf[T]{}
+	}
+}
+
+// Reset points the accessor to arr, as if the accessor was created by NewStringOf with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *StringOf[T]) Reset(arr arrow.Array) error {
+	prev := a.Array
+	if err := a.bind(arr); err != nil {
+		return err
+	}
+	arr.Retain()
+	if prev != nil {
+		prev.Release()
+	}
+
+	return nil
+}
+
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *StringOf[T]) Slice(i, j int) *StringOf[T] {
+	r := &StringOf[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+	if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+		panic(err)
+	}
+
+	return r
+}
 
-		default:
-			return nil, fmt.Errorf("cannot use %s dictionary for string", dt.ValueType.String())
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *StringOf[T]) bind(arr arrow.Array) error {
+	if a.opts.lossless {
+		if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+			return &LossyConversionError{Conversion: c}
 		}
+	}
+
+	r := StringOf[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
+
+	v, ok := arr.(*array.Dictionary)
+	if !ok {
+		values, ok := stringBuffer(arr)
+		if !ok {
+			return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
+		}
+		r.values = values
+		r.direct, _ = arr.(*array.String)
+		*a = r
+
+		return nil
+	}
+
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+		return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values, ok := stringBuffer(v.Dictionary())
+	if !ok {
+		return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+	}
+	values.setValidity(v.Dictionary())
+	r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+	if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+		r.decoded = a.decoded
+	}
+	*a = r
 
+	if a.opts.decodeDictionary && a.decoded == nil {
+		a.decoded = make([]T, a.values.len())
+		for j := range a.decoded {
+			a.decoded[j] = a.value(j)
+		}
+	}
+	if a.values.valid != nil {
+		for i := range arr.Len() {
+			if a.IsNull(i) {
+				a.nulls++
+			}
+		}
+	}
+
+	return nil
+}
+
+// stringBuffer creates the [buffer] for the arrays that can be converted to string.
+func stringBuffer(a arrow.Array) (buffer, bool) {
+	switch v := a.(type) {
+	case *array.String:
+		return binaryBuffer(v), true
+	case *array.Binary:
+		return binaryBuffer(v), true
+	case *array.LargeString:
+		return largeBinaryBuffer(v), true
+	case *array.LargeBinary:
+		return largeBinaryBuffer(v), true
+	case *array.Null:
+		return nullBuffer(v), true
 	default:
-		return nil, fmt.Errorf("cannot use %s for gotype string", a.String())
+		return buffer{}, false
 	}
 }
@@ -0,0 +1,161 @@
+package anyarrow_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+const benchLen = 1 << 16
+
+func benchFloat64Array() arrow.Array {
+	ab := array.NewFloat64Builder(memory.DefaultAllocator)
+	defer ab.Release()
+	for i := 0; i < benchLen; i++ {
+		ab.Append(float64(i))
+	}
+
+	return ab.NewArray()
+}
+
+func benchInt32Array() arrow.Array {
+	ab := array.NewInt32Builder(memory.DefaultAllocator)
+	defer ab.Release()
+	for i := 0; i < benchLen; i++ {
+		ab.Append(int32(i))
+	}
+
+	return ab.NewArray()
+}
+
+func benchDictionaryArray(valueType arrow.DataType) arrow.Array {
+	ab := array.NewDictionaryBuilder(memory.DefaultAllocator, &arrow.DictionaryType{
+		IndexType: arrow.PrimitiveTypes.Int16,
+		ValueType: valueType,
+	})
+	defer ab.Release()
+	for i := 0; i < benchLen; i++ {
+		switch b := ab.(type) {
+		case *array.Float64DictionaryBuilder:
+			if err := b.Append(float64(i % 1000)); err != nil {
+				panic(err)
+			}
+		case *array.BinaryDictionaryBuilder:
+			if err := b.AppendString(strconv.Itoa(i % 1000)); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return ab.NewArray()
+}
+
+func benchStringArray() arrow.Array {
+	ab := array.NewStringBuilder(memory.DefaultAllocator)
+	defer ab.Release()
+	for i := 0; i < benchLen; i++ {
+		ab.Append(strconv.Itoa(i))
+	}
+
+	return ab.NewArray()
+}
+
+func benchmarkFloat64Value(b *testing.B, a arrow.Array, opts ...anyarrow.Option) {
+	defer a.Release()
+	f64, err := anyarrow.NewFloat64(a, opts...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f64.Release()
+	b.ResetTimer()
+	var sum float64
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < benchLen; i++ {
+			sum += f64.Value(i)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/benchLen, "ns/elem")
+	_ = sum
+}
+
+func benchmarkFloat64CopyTo(b *testing.B, a arrow.Array, opts ...anyarrow.Option) {
+	defer a.Release()
+	f64, err := anyarrow.NewFloat64(a, opts...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f64.Release()
+	dst := make([]float64, benchLen)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		f64.CopyTo(dst)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/benchLen, "ns/elem")
+}
+
+func benchmarkStringValue(b *testing.B, a arrow.Array, opts ...anyarrow.Option) {
+	defer a.Release()
+	s, err := anyarrow.NewString(a, opts...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer s.Release()
+	b.ResetTimer()
+	var l int
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < benchLen; i++ {
+			l += len(s.Value(i))
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/benchLen, "ns/elem")
+	_ = l
+}
+
+func BenchmarkValue_direct(b *testing.B) {
+	benchmarkFloat64Value(b, benchFloat64Array())
+}
+
+func BenchmarkValue_cast(b *testing.B) {
+	benchmarkFloat64Value(b, benchInt32Array())
+}
+
+func BenchmarkValue_dictionary(b *testing.B) {
+	benchmarkFloat64Value(b, benchDictionaryArray(arrow.PrimitiveTypes.Float64))
+}
+
+func BenchmarkValue_decodedDictionary(b *testing.B) {
+	benchmarkFloat64Value(b, benchDictionaryArray(arrow.PrimitiveTypes.Float64), anyarrow.WithDecodedDictionary())
+}
+
+func BenchmarkValue_string(b *testing.B) {
+	benchmarkStringValue(b, benchStringArray())
+}
+
+func BenchmarkValue_stringDictionary(b *testing.B) {
+	benchmarkStringValue(b, benchDictionaryArray(arrow.BinaryTypes.String))
+}
+
+func BenchmarkValue_decodedStringDictionary(b *testing.B) {
+	benchmarkStringValue(b, benchDictionaryArray(arrow.BinaryTypes.String), anyarrow.WithDecodedDictionary())
+}
+
+func BenchmarkCopyTo_direct(b *testing.B) {
+	benchmarkFloat64CopyTo(b, benchFloat64Array())
+}
+
+func BenchmarkCopyTo_cast(b *testing.B) {
+	benchmarkFloat64CopyTo(b, benchInt32Array())
+}
+
+func BenchmarkCopyTo_dictionary(b *testing.B) {
+	benchmarkFloat64CopyTo(b, benchDictionaryArray(arrow.PrimitiveTypes.Float64))
+}
+
+func BenchmarkCopyTo_decodedDictionary(b *testing.B) {
+	benchmarkFloat64CopyTo(b, benchDictionaryArray(arrow.PrimitiveTypes.Float64), anyarrow.WithDecodedDictionary())
+}
@@ -0,0 +1,265 @@
+package anyarrow
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+)
+
+// BigInt provides access to [arrow.Array]'s elements as [big.Int], which represents them exactly.
+//
+// It accepts the integer arrays accepted by [Int64] and [Uint64], including the temporal ones,
+// decimals whose scale is not positive, strings and binaries of integers in base 10,
+// nulls, and dictionaries of these values.
+type BigInt struct {
+	arrowArray
+
+	// values of the array, or values of the dictionary if the array is a dictionary.
+	values buffer
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+	// scale is the power of ten the decimal values are multiplied by, only set for decimals of negative scale.
+	scale *big.Int
+
+	refCount int64
+}
+
+var _ arrow.Array = (*BigInt)(nil)
+
+// NewBigInt wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+//
+// An [*UnsupportedTypeError] or an [*UnsupportedDictionaryError] is returned if the array cannot be read as integers,
+// and an error is returned if a string is not an integer.
+func NewBigInt(a arrow.Array) (*BigInt, error) {
+	r := &BigInt{arrowArray: arrowArray{Array: a}, refCount: 1}
+
+	v, ok := a.(*array.Dictionary)
+	if !ok {
+		values, ok := bigIntBuffer(a)
+		if !ok {
+			return nil, &UnsupportedTypeError{DataType: a.DataType(), GoType: bigIntType}
+		}
+		r.values = values
+	} else {
+		dt, ok := v.DataType().(*arrow.DictionaryType)
+		if !ok {
+			return nil, &DictionaryMismatchError{DataType: v.DataType(), GoType: bigIntType}
+		}
+		indices, ok := indexBuffer(v.Indices())
+		if !ok {
+			return nil, &UnsupportedDictionaryError{DataType: dt, GoType: bigIntType}
+		}
+		values, ok := bigIntBuffer(v.Dictionary())
+		if !ok {
+			return nil, &UnsupportedDictionaryError{DataType: dt, GoType: bigIntType}
+		}
+		values.setValidity(v.Dictionary())
+		r.values, r.indices = values, indices
+		if values.valid != nil {
+			for i := range a.Len() {
+				if r.IsNull(i) {
+					r.nulls++
+				}
+			}
+		}
+	}
+
+	if err := r.checkStrings(); err != nil {
+		return nil, err
+	}
+	if r.values.scale < 0 {
+		r.scale = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-r.values.scale)), nil)
+	}
+	a.Retain()
+
+	return r, nil
+}
+
+var bigIntType = reflect.TypeFor[*big.Int]()
+
+// bigIntBuffer creates the buffer of arr if its values are integers.
+func bigIntBuffer(arr arrow.Array) (buffer, bool) {
+	if b, ok := stringBuffer(arr); ok {
+		return b, true
+	}
+
+	b, ok := int64Buffer(arr)
+	switch {
+	case !ok:
+		return b, false
+	case b.kind == kindFloat32 || b.kind == kindFloat64 || b.kind == kindBoolean:
+		return buffer{}, false
+	case (b.kind == kindDecimal128 || b.kind == kindDecimal256) && b.scale > 0:
+		return buffer{}, false
+	default:
+		return b, true
+	}
+}
+
+// checkStrings checks that the valid string values are integers.
+func (a *BigInt) checkStrings() error {
+	if a.values.kind != kindBinary && a.values.kind != kindLargeBinary {
+		return nil
+	}
+
+	var z big.Int
+	for j := range a.values.len() {
+		if !a.values.isValid(j) || a.indices.kind == kindUnknown && a.Array.IsNull(j) {
+			continue
+		}
+		if _, ok := z.SetString(a.values.str(j), 10); !ok {
+			return fmt.Errorf("cannot use %q at index %d of %s as an integer", a.values.str(j), j, a.DataType())
+		}
+	}
+
+	return nil
+}
+
+// Value retrieves the element at index i as a newly allocated [big.Int].
+func (a *BigInt) Value(i int) *big.Int {
+	return a.ValueTo(new(big.Int), i)
+}
+
+// ValueTo sets z to the element at index i and returns z,
+// which doesn't allocate once z is large enough to hold the elements, except for decimals of negative scale.
+func (a *BigInt) ValueTo(z *big.Int, i int) *big.Int {
+	if a.indices.kind != kindUnknown {
+		i = a.indices.index(i)
+	}
+
+	return a.value(z, i)
+}
+
+// ValueOk retrieves the element at index i as a newly allocated [big.Int], and whether it is valid.
+// nil is returned for nulls.
+func (a *BigInt) ValueOk(i int) (*big.Int, bool) {
+	if !a.IsValid(i) {
+		return nil, false
+	}
+
+	return a.Value(i), true
+}
+
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *BigInt) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.indices.kind != kindUnknown {
+		return a.values.isValid(a.indices.index(i))
+	}
+
+	return a.values.kind != kindNull
+}
+
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *BigInt) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
+
+// NullN returns the number of null elements, see IsValid.
+func (a *BigInt) NullN() int {
+	if a.values.kind == kindNull {
+		return a.Len()
+	}
+	if a.indices.kind != kindUnknown && a.values.valid != nil {
+		return a.nulls
+	}
+
+	return a.Array.NullN()
+}
+
+// value sets z to the j-th value of the values.
+func (a *BigInt) value(z *big.Int, j int) *big.Int {
+	switch a.values.kind {
+	case kindInt8:
+		return z.SetInt64(int64(a.values.i8[j]))
+	case kindInt16:
+		return z.SetInt64(int64(a.values.i16[j]))
+	case kindInt32:
+		return z.SetInt64(int64(a.values.i32[j]))
+	case kindInt64:
+		return z.SetInt64(a.values.i64[j])
+	case kindUint8:
+		return z.SetUint64(uint64(a.values.u8[j]))
+	case kindUint16:
+		return z.SetUint64(uint64(a.values.u16[j]))
+	case kindUint32:
+		return z.SetUint64(uint64(a.values.u32[j]))
+	case kindUint64:
+		return z.SetUint64(a.values.u64[j])
+	case kindBinary, kindLargeBinary:
+		z.SetString(a.values.str(j), 10)
+		return z
+	case kindDecimal128:
+		n := a.values.d128[j]
+		negative := n.Sign() < 0
+		if negative {
+			n = n.Negate()
+		}
+		return a.scaleUp(setWords(z, [4]uint64{n.LowBits(), uint64(n.HighBits())}, negative))
+	case kindDecimal256:
+		n := a.values.d256[j]
+		negative := n.Sign() < 0
+		if negative {
+			n = n.Negate()
+		}
+		return a.scaleUp(setWords(z, n.Array(), negative))
+	case kindNull:
+		return z.SetInt64(0)
+	default:
+		panic("uninitialized accessor for go type *big.Int")
+	}
+}
+
+// scaleUp multiplies z by the power of ten of decimals with negative scale.
+func (a *BigInt) scaleUp(z *big.Int) *big.Int {
+	if a.scale == nil {
+		return z
+	}
+
+	return z.Mul(z, a.scale)
+}
+
+// setWords sets z to the absolute value in the little endian words, negated if negative, reusing the memory of z.
+func setWords(z *big.Int, words [4]uint64, negative bool) *big.Int {
+	abs := z.Bits()[:0]
+	for _, w := range words {
+		abs = append(abs, big.Word(w))
+		if bits.UintSize == 32 {
+			abs = append(abs, big.Word(w>>32))
+		}
+	}
+	z.SetBits(abs)
+	if negative {
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// Retain increases the reference count of the accessor by 1.
+func (a *BigInt) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
+
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *BigInt) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = BigInt{}
+	}
+}
@@ -0,0 +1,150 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+
+	return n
+}
+
+func TestBigInt(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tests := []struct {
+		dt       arrow.DataType
+		json     string
+		expected []string
+	}{
+		{arrow.PrimitiveTypes.Int8, `[-128, null, 127]`, []string{"-128", "", "127"}},
+		{arrow.FixedWidthTypes.Timestamp_s, `[1]`, []string{"1"}},
+		{
+			&arrow.Decimal128Type{Precision: 38, Scale: 0},
+			`["-99999999999999999999999999999999999999", "12"]`,
+			[]string{"-99999999999999999999999999999999999999", "12"},
+		},
+		{
+			&arrow.Decimal256Type{Precision: 76, Scale: 0},
+			`["-1234567890123456789012345678901234567890123456789012345678901234567890"]`,
+			[]string{"-1234567890123456789012345678901234567890123456789012345678901234567890"},
+		},
+		{&arrow.Decimal128Type{Precision: 5, Scale: -3}, `["-12000"]`, []string{"-12000"}},
+		{arrow.BinaryTypes.LargeString, `["123456789012345678901234567890", null]`, []string{"123456789012345678901234567890", ""}},
+	}
+
+	for _, test := range tests {
+		arr, _, err := array.FromJSON(mem, test.dt, strings.NewReader(test.json))
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		a, err := anyarrow.NewBigInt(arr)
+		arr.Release()
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		for i, expected := range test.expected {
+			v, ok := a.ValueOk(i)
+			switch {
+			case expected == "" && ok:
+				t.Errorf("%s at %d: expected null, got %s", test.dt, i, v)
+			case expected != "" && (!ok || v.Cmp(bigInt(t, expected)) != 0):
+				t.Errorf("%s at %d: expected %s, got %s", test.dt, i, expected, v)
+			}
+		}
+		a.Release()
+	}
+}
+
+func TestBigIntDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	strs := anyarrow.FromStrings(mem, []string{"1", "-99999999999999999999", "x"}, []bool{true, true, false})
+	defer strs.Release()
+	dict := newDictionary(t, mem, []int16{1, 0, 2, 1}, []bool{true, false, true, true}, strs)
+	defer dict.Release()
+
+	a, err := anyarrow.NewBigInt(dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Release()
+
+	if a.NullN() != 2 || a.IsValid(1) || a.IsValid(2) {
+		t.Errorf("expected elements 1 and 2 to be null, got %d nulls", a.NullN())
+	}
+	expected := bigInt(t, "-99999999999999999999")
+	if a.Value(0).Cmp(expected) != 0 || a.Value(3).Cmp(expected) != 0 {
+		t.Errorf("expected %s, got %s and %s", expected, a.Value(0), a.Value(3))
+	}
+}
+
+func TestBigIntErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	floats := anyarrow.FromSlice([]float64{1}, nil)
+	defer floats.Release()
+	db := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 10, Scale: 2})
+	defer db.Release()
+	decimals := db.NewArray()
+	defer decimals.Release()
+	strs := anyarrow.FromStrings(mem, []string{"1", "1.5"}, nil)
+	defer strs.Release()
+
+	var typeErr *anyarrow.UnsupportedTypeError
+	for _, arr := range []arrow.Array{floats, decimals} {
+		if _, err := anyarrow.NewBigInt(arr); !errors.As(err, &typeErr) {
+			t.Errorf("%s: expected UnsupportedTypeError, got %v", arr.DataType(), err)
+		}
+	}
+	if _, err := anyarrow.NewBigInt(strs); err == nil || !strings.Contains(err.Error(), `"1.5" at index 1`) {
+		t.Errorf("expected error for non integer string, got %v", err)
+	}
+}
+
+func TestBigIntValueTo(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dt := &arrow.Decimal256Type{Precision: 76, Scale: 0}
+	arr, _, err := array.FromJSON(mem, dt, strings.NewReader(`["-1234567890123456789012345678901234567890", "7"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer arr.Release()
+	uints := anyarrow.FromSlice([]uint64{math.MaxUint64}, nil)
+	defer uints.Release()
+
+	for _, arr := range []arrow.Array{arr, uints} {
+		a, err := anyarrow.NewBigInt(arr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z := a.Value(0)
+		if arr == uints && z.Cmp(new(big.Int).SetUint64(math.MaxUint64)) != 0 {
+			t.Errorf("expected max uint64, got %s", z)
+		}
+		if allocs := testing.AllocsPerRun(100, func() { a.ValueTo(z, a.Len()-1) }); allocs != 0 {
+			t.Errorf("%s: expected ValueTo not to allocate, got %v allocations", arr.DataType(), allocs)
+		}
+		a.Release()
+	}
+}
@@ -0,0 +1,250 @@
+package anyarrow
+
+import (
+	"unsafe"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/bitutil"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/decimal256"
+)
+
+// kind is the physical layout of the values held by a [buffer].
+type kind uint8
+
+const (
+	kindUnknown kind = iota
+	kindInt8
+	kindInt16
+	kindInt32
+	kindInt64
+	kindUint8
+	kindUint16
+	kindUint32
+	kindUint64
+	kindFloat32
+	kindFloat64
+	// kindBinary is variable width data with int32 offsets.
+	kindBinary
+	// kindLargeBinary is variable width data with int64 offsets.
+	kindLargeBinary
+	// kindBoolean is bit packed booleans.
+	kindBoolean
+	kindDecimal128
+	kindDecimal256
+	// kindNull is the values of a null array, which are all null and have no storage.
+	kindNull
+)
+
+// buffer is the value buffer of an [arrow.Array], precomputed so elements can be read without going through the array.
+//
+// Only the slice corresponding to kind is set, and all of them already account for the offset of the array.
+type buffer struct {
+	kind kind
+
+	i8  []int8
+	i16 []int16
+	i32 []int32
+	i64 []int64
+	u8  []uint8
+	u16 []uint16
+	u32 []uint32
+	u64 []uint64
+	f32 []float32
+	f64 []float64
+
+	// offsets for kindBinary, and largeOffsets for kindLargeBinary.
+	// they index into data, which shares the memory of the array.
+	offsets      []int32
+	largeOffsets []int64
+	data         string
+
+	// bits and bitOffset for kindBoolean, where length is the number of booleans.
+	// length is also the number of elements for kindNull.
+	bits      []byte
+	bitOffset int
+	length    int
+
+	// d128 for kindDecimal128 and d256 for kindDecimal256, both with scale.
+	d128  []decimal128.Num
+	d256  []decimal256.Num
+	scale int32
+
+	// valid and validOffset are the validity bitmap of the values,
+	// only set by setValidity when the values contain nulls.
+	valid       []byte
+	validOffset int
+}
+
+// setValidity sets the validity bitmap of the buffer from arr, which the buffer is created from.
+func (b *buffer) setValidity(arr arrow.Array) {
+	if arr.NullN() == 0 {
+		return
+	}
+
+	b.valid, b.validOffset = arr.NullBitmapBytes(), arr.Data().Offset()
+}
+
+// isValid checks if the i-th element of the buffer is valid, see setValidity.
+//
+// The elements of a [kindNull] buffer are never valid.
+func (b *buffer) isValid(i int) bool {
+	return b.kind != kindNull && (b.valid == nil || bitutil.BitIsSet(b.valid, b.validOffset+i))
+}
+
+// bufferData returns the data of buffers[i] of arr as a string without copying.
+func bufferData(arr arrow.Array, i int) string {
+	buffers := arr.Data().Buffers()
+	if len(buffers) <= i || buffers[i] == nil {
+		return ""
+	}
+
+	b := buffers[i].Bytes()
+
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// binaryArray is implemented by [array.String] and [array.Binary].
+type binaryArray interface {
+	arrow.Array
+	ValueOffsets() []int32
+}
+
+// largeBinaryArray is implemented by [array.LargeString] and [array.LargeBinary].
+type largeBinaryArray interface {
+	arrow.Array
+	ValueOffsets() []int64
+}
+
+// binaryBuffer creates a [kindBinary] buffer from arr.
+func binaryBuffer(arr binaryArray) buffer {
+	if arr.Len() == 0 {
+		return buffer{kind: kindBinary, offsets: []int32{0}}
+	}
+
+	return buffer{kind: kindBinary, offsets: arr.ValueOffsets(), data: bufferData(arr, 2)}
+}
+
+// largeBinaryBuffer creates a [kindLargeBinary] buffer from arr.
+func largeBinaryBuffer(arr largeBinaryArray) buffer {
+	if arr.Len() == 0 {
+		return buffer{kind: kindLargeBinary, largeOffsets: []int64{0}}
+	}
+
+	return buffer{kind: kindLargeBinary, largeOffsets: arr.ValueOffsets(), data: bufferData(arr, 2)}
+}
+
+// booleanBuffer creates a [kindBoolean] buffer from arr.
+func booleanBuffer(arr *array.Boolean) buffer {
+	b := buffer{kind: kindBoolean, bitOffset: arr.Data().Offset(), length: arr.Len()}
+	if buffers := arr.Data().Buffers(); len(buffers) > 1 && buffers[1] != nil {
+		b.bits = buffers[1].Bytes()
+	}
+
+	return b
+}
+
+// decimal128Buffer creates a [kindDecimal128] buffer from arr.
+func decimal128Buffer(arr *array.Decimal128) buffer {
+	return buffer{kind: kindDecimal128, d128: arr.Values(), scale: arr.DataType().(*arrow.Decimal128Type).Scale}
+}
+
+// decimal256Buffer creates a [kindDecimal256] buffer from arr.
+func decimal256Buffer(arr *array.Decimal256) buffer {
+	return buffer{kind: kindDecimal256, d256: arr.Values(), scale: arr.DataType().(*arrow.Decimal256Type).Scale}
+}
+
+// nullBuffer creates a [kindNull] buffer from arr.
+func nullBuffer(arr *array.Null) buffer {
+	return buffer{kind: kindNull, length: arr.Len()}
+}
+
+// indexBuffer creates a buffer from the indices of a dictionary.
+//
+// Following arrow, indices are read as unsigned integers since they are non-negative per the spec.
+func indexBuffer(indices arrow.Array) (buffer, bool) {
+	switch v := indices.(type) {
+	case *array.Int8:
+		return buffer{kind: kindUint8, u8: reinterpretSlice[uint8](v.Int8Values())}, true
+	case *array.Uint8:
+		return buffer{kind: kindUint8, u8: v.Uint8Values()}, true
+	case *array.Int16:
+		return buffer{kind: kindUint16, u16: reinterpretSlice[uint16](v.Int16Values())}, true
+	case *array.Uint16:
+		return buffer{kind: kindUint16, u16: v.Uint16Values()}, true
+	case *array.Int32:
+		return buffer{kind: kindUint32, u32: reinterpretSlice[uint32](v.Int32Values())}, true
+	case *array.Uint32:
+		return buffer{kind: kindUint32, u32: v.Uint32Values()}, true
+	case *array.Int64:
+		return buffer{kind: kindUint64, u64: reinterpretSlice[uint64](v.Int64Values())}, true
+	case *array.Uint64:
+		return buffer{kind: kindUint64, u64: v.Uint64Values()}, true
+	default:
+		return buffer{}, false
+	}
+}
+
+// index reads the i-th element of an index buffer created by [indexBuffer].
+func (b *buffer) index(i int) int {
+	switch b.kind {
+	case kindUint8:
+		return int(b.u8[i])
+	case kindUint16:
+		return int(b.u16[i])
+	case kindUint32:
+		return int(b.u32[i])
+	case kindUint64:
+		return int(b.u64[i])
+	default:
+		panic("buffer is not a dictionary index")
+	}
+}
+
+// len is the number of elements in the buffer.
+func (b *buffer) len() int {
+	switch b.kind {
+	case kindInt8:
+		return len(b.i8)
+	case kindInt16:
+		return len(b.i16)
+	case kindInt32:
+		return len(b.i32)
+	case kindInt64:
+		return len(b.i64)
+	case kindUint8:
+		return len(b.u8)
+	case kindUint16:
+		return len(b.u16)
+	case kindUint32:
+		return len(b.u32)
+	case kindUint64:
+		return len(b.u64)
+	case kindFloat32:
+		return len(b.f32)
+	case kindFloat64:
+		return len(b.f64)
+	case kindBinary:
+		return len(b.offsets) - 1
+	case kindLargeBinary:
+		return len(b.largeOffsets) - 1
+	case kindBoolean, kindNull:
+		return b.length
+	case kindDecimal128:
+		return len(b.d128)
+	case kindDecimal256:
+		return len(b.d256)
+	default:
+		return 0
+	}
+}
+
+// str is the i-th element of a [kindBinary] or [kindLargeBinary] buffer.
+func (b *buffer) str(i int) string {
+	if b.kind == kindLargeBinary {
+		return b.data[b.largeOffsets[i]:b.largeOffsets[i+1]]
+	}
+
+	return b.data[b.offsets[i]:b.offsets[i+1]]
+}
@@ -0,0 +1,111 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Structs}}{{$s := .}}
+var {{.Schema}} = arrow.NewSchema([]arrow.Field{
+{{- range .Fields}}
+	{Name: {{printf "%q" .Column}}, Type: {{.DataType}}{{if .Nullable}}, Nullable: true{{end}}},
+{{- end}}
+}, nil)
+
+// {{.Marshal}} encodes rows into an [arrow.Record] allocated by mem, like [anyarrow.Marshal] but without reflection.
+//
+// The caller must release the returned record.
+func {{.Marshal}}(mem memory.Allocator, rows []{{.Name}}) (arrow.Record, error) {
+	b := array.NewRecordBuilder(mem, {{.Schema}})
+	defer b.Release()
+	b.Reserve(len(rows))
+{{range $k, $f := .Fields}}
+	{{.Var}}Builder := b.Field({{$k}}).({{.Builder}})
+	for i := range rows {
+		{{.Encode}}
+	}
+{{end}}
+	return b.NewRecord(), nil
+}
+
+// {{.Unmarshal}} decodes the rows of rec into dst, which is resized to the number of rows of rec,
+// like [anyarrow.Unmarshal] but without reflection.
+// The decoded values don't share memory with rec, which can be released right after.
+func {{.Unmarshal}}(rec arrow.Record, dst *[]{{.Name}}) error {
+	d, err := {{.NewDecoder}}(rec)
+	if err != nil {
+		return err
+	}
+	defer d.Release()
+
+	n := int(rec.NumRows())
+	*dst = slices.Grow((*dst)[:0], n)[:n]
+	for i := range *dst {
+		(*dst)[i] = {{.Name}}{}
+		d.DecodeRow(i, &(*dst)[i])
+	}
+
+	return nil
+}
+
+// {{.Decoder}} decodes the rows of an [arrow.Record] into [{{.Name}}], like [anyarrow.Decoder] but without reflection.
+type {{.Decoder}} struct {
+{{- range .Fields}}
+	{{.Var}} {{.AccessorType}}
+	{{- if .Converter}}
+	{{.Converter}} {{.ConverterType}}
+	{{- end}}
+{{- end}}
+}
+
+// {{.NewDecoder}} creates a [{{.Decoder}}] for the rows of rec.
+//
+// The returned error lists every column that is missing or cannot be decoded into its field.
+func {{.NewDecoder}}(rec arrow.Record) (*{{.Decoder}}, error) {
+	d := &{{.Decoder}}{}
+	schema := rec.Schema()
+	var errs []error
+	var err error
+{{range .Fields}}
+	if k := schema.FieldIndices({{printf "%q" .Column}}); len(k) == 0 {
+		errs = append(errs, {{.MissingError}})
+	{{- if and .Converter (not .Nanoseconds)}}
+	} else if d.{{.Converter}}, err = {{.NewConverter}}(schema.Field(k[0]).Type); err != nil {
+		errs = append(errs, {{.DecodeError}})
+	{{- end}}
+	} else if d.{{.Var}}, err = {{.NewAccessor}}(rec.Column(k[0])); err != nil {
+		errs = append(errs, {{.DecodeError}})
+	{{- if .Nanoseconds}}
+	} else if d.{{.Converter}}, err = {{.NewConverter}}(schema.Field(k[0]).Type); err != nil {
+		// like anyarrow.Unmarshal, columns other than durations are read as nanoseconds.
+		d.{{.Converter}} = func(x int64) time.Duration { return time.Duration(x) }
+	{{- end}}
+	}
+{{end}}
+	if len(errs) > 0 {
+		d.Release()
+		return nil, errors.Join(errs...)
+	}
+
+	return d, nil
+}
+
+// DecodeRow decodes row i into v.
+// Strings and []byte are copied, so v remains valid after the record is released.
+func (d *{{.Decoder}}) DecodeRow(i int, v *{{.Name}}) {
+{{- range .Fields}}
+	{{.Decode}}
+{{- end}}
+}
+
+// Release releases the accessors held by the decoder.
+func (d *{{.Decoder}}) Release() {
+{{- range .Fields}}
+	if d.{{.Var}} != nil {
+		d.{{.Var}}.Release()
+	}
+{{- end}}
+}
+{{end}}
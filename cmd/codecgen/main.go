@@ -0,0 +1,784 @@
+// Command codecgen generates functions that encode and decode go structs to and from arrow records without reflection.
+//
+// It is the generated counterpart of [anyarrow.Marshal] and [anyarrow.Unmarshal],
+// with the same `arrow:"name,options"` struct tags. For a struct type Trade, it generates
+//   - MarshalTrade, which encodes a slice of Trade into a record.
+//   - UnmarshalTrade, which decodes a record into a slice of Trade.
+//   - TradeDecoder and NewTradeDecoder, which decode the rows of a record one by one.
+//
+// The names are unexported if the struct type is unexported.
+// Use it with go generate in the package of the struct types:
+//
+//	//go:generate go run github.com/fardream/anyarrow/cmd/codecgen -type Trade,Quote
+//
+// Fields can be booleans, numbers, strings, []byte, time.Time, time.Duration, or types with such underlying types,
+// and pointers and [anyarrow.Nullable] of them. Nested structs and lists are not supported,
+// and should be encoded and decoded by [anyarrow.Marshal] and [anyarrow.Unmarshal].
+// As with [anyarrow.Unmarshal], time.Duration fields are decoded from duration columns with the unit of the column,
+// and from other integer columns as nanoseconds.
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/fardream/anyarrow/internal/tag"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"mvdan.cc/gofumpt/format"
+)
+
+func orpanic(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func must[T any](a T, err error) T {
+	orpanic(err)
+
+	return a
+}
+
+//go:embed codec.go.tpl
+var codecGoTplStr string
+
+const anyarrowPath = "github.com/fardream/anyarrow"
+
+// leaf is how the go type of a field is mapped to arrow, after removing the pointer or [anyarrow.Nullable].
+type leaf int
+
+const (
+	leafBool leaf = iota
+	leafInt
+	leafUint
+	leafFloat
+	leafString
+	leafBytes
+	leafTime
+	leafDuration
+)
+
+// wrapper is the wrapper around the leaf type of a field.
+type wrapper int
+
+const (
+	wrapNone wrapper = iota
+	wrapPointer
+	wrapNullable
+)
+
+// Field is a field of the struct mapped to an arrow column.
+type Field struct {
+	// Column is the name of the arrow column.
+	Column string
+	// Path is the selector of the field from the struct, such as Meta.Source for promoted fields.
+	Path string
+	// Var is the identifier of the field in the decoder and the builder of the field in the encoder.
+	Var string
+	// Desc describes the field in error messages, such as trade.Trade.Price.
+	Desc string
+
+	leaf    leaf
+	wrapper wrapper
+	// goType is the leaf type in the generated code.
+	goType string
+	// basic is the underlying type of the leaf, such as int32.
+	basic   string
+	options tag.Options
+}
+
+// Struct is a struct type to generate the codec for.
+type Struct struct {
+	Name   string
+	Fields []Field
+	// exported indicates if the generated functions and types are exported.
+	exported bool
+}
+
+func (s Struct) ident(name string) string {
+	if s.exported {
+		return name
+	}
+
+	return string(unicode.ToLower(rune(name[0]))) + name[1:]
+}
+
+// Decoder is the name of the decoder type.
+func (s Struct) Decoder() string {
+	return s.ident(s.Name + "Decoder")
+}
+
+// NewDecoder is the name of the function creating the decoder.
+func (s Struct) NewDecoder() string {
+	return s.ident("New" + s.Name + "Decoder")
+}
+
+// Marshal is the name of the encoding function.
+func (s Struct) Marshal() string {
+	return s.ident("Marshal" + s.Name)
+}
+
+// Unmarshal is the name of the decoding function.
+func (s Struct) Unmarshal() string {
+	return s.ident("Unmarshal" + s.Name)
+}
+
+// Schema is the name of the variable holding the arrow schema.
+func (s Struct) Schema() string {
+	return "schemaOf" + s.Name
+}
+
+// accessors are the accessors reading the basic types.
+var accessors = map[string]string{
+	"int8":    "Int8",
+	"int16":   "Int16",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"uint8":   "Uint8",
+	"uint16":  "Uint16",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"float32": "Float32",
+	"float64": "Float64",
+	"string":  "String",
+}
+
+// accessor is the accessor reading the field and the go type it reads into.
+func (f Field) accessor() (name, elem string) {
+	switch f.leaf {
+	case leafBool:
+		return "Float64", "float64"
+	case leafBytes:
+		return "String", "string"
+	case leafTime, leafDuration:
+		return "Int64", "int64"
+	}
+	if name, ok := accessors[f.basic]; ok {
+		return name, f.basic
+	}
+	if f.leaf == leafInt {
+		return "Int64", "int64"
+	}
+
+	return "Uint64", "uint64"
+}
+
+// generic checks if the field is read by the generic accessor with the leaf type as type parameter.
+func (f Field) generic() bool {
+	_, elem := f.accessor()
+
+	return elem == f.basic && f.goType != f.basic && f.Converter() == ""
+}
+
+// AccessorType is the type of the accessor in the decoder.
+func (f Field) AccessorType() string {
+	name, _ := f.accessor()
+	if f.generic() {
+		return "*anyarrow." + name + "Of[" + f.goType + "]"
+	}
+
+	return "*anyarrow." + name
+}
+
+// NewAccessor is the function creating the accessor.
+func (f Field) NewAccessor() string {
+	name, _ := f.accessor()
+	if f.generic() {
+		return "anyarrow.New" + name + "Of[" + f.goType + "]"
+	}
+
+	return "anyarrow.New" + name
+}
+
+// Converter is the field of the decoder holding the function converting the values of the accessor, if any.
+func (f Field) Converter() string {
+	switch f.leaf {
+	case leafTime:
+		return f.Var + "ToTime"
+	case leafDuration:
+		return f.Var + "ToDuration"
+	default:
+		return ""
+	}
+}
+
+// ConverterType is the type of [Field.Converter].
+func (f Field) ConverterType() string {
+	if f.leaf == leafTime {
+		return "func(int64) time.Time"
+	}
+
+	return "func(int64) time.Duration"
+}
+
+// Nanoseconds indicates the column is read as nanoseconds unless it is a duration column, like [anyarrow.Unmarshal],
+// so [Field.NewConverter] failing is not an error.
+func (f Field) Nanoseconds() bool {
+	return f.leaf == leafDuration
+}
+
+// NewConverter is the function creating [Field.Converter] from the data type of the column.
+func (f Field) NewConverter() string {
+	if f.leaf == leafTime {
+		return "anyarrow.TimeFunc"
+	}
+
+	return "anyarrow.DurationFunc"
+}
+
+// decodeValue is the expression converting x read by the accessor to the leaf type, stored into dst.
+// []byte reuses the memory of dst unless dst is empty.
+func (f Field) decodeValue(dst string) string {
+	_, elem := f.accessor()
+	switch {
+	case f.leaf == leafBool:
+		return "x != 0"
+	case f.leaf == leafBytes && dst == "":
+		return "append(" + f.goType + "(nil), x...)"
+	case f.leaf == leafBytes:
+		return "append(" + dst + "[:0], x...)"
+	case f.leaf == leafString && f.goType != "string":
+		return f.goType + "(strings.Clone(string(x)))"
+	case f.leaf == leafString:
+		// x shares the memory of the record, which may be released and reused.
+		return "strings.Clone(x)"
+	case f.Converter() != "":
+		return "d." + f.Converter() + "(x)"
+	case elem == f.basic:
+		return "x"
+	default:
+		return f.goType + "(x)"
+	}
+}
+
+// zero is the zero value of the leaf type.
+func (f Field) zero() string {
+	switch f.leaf {
+	case leafBool:
+		return "false"
+	case leafString:
+		return `""`
+	case leafBytes:
+		return "nil"
+	case leafTime:
+		return "time.Time{}"
+	default:
+		return "0"
+	}
+}
+
+// Decode is the statement decoding row i into v.
+func (f Field) Decode() string {
+	dst := "v." + f.Path
+	var valid, null string
+	switch f.wrapper {
+	case wrapPointer:
+		valid = "y := " + f.decodeValue("") + "\n" + dst + " = &y"
+		null = dst + " = nil"
+	case wrapNullable:
+		valid = dst + ".Value = " + f.decodeValue(dst+".Value") + "\n" + dst + ".Valid = true"
+		null = dst + " = anyarrow.Nullable[" + f.goType + "]{}"
+	default:
+		valid = dst + " = " + f.decodeValue(dst)
+		null = dst + " = " + f.zero()
+	}
+
+	return "if x, ok := d." + f.Var + ".ValueOk(i); ok {\n" + valid + "\n} else {\n" + null + "\n}"
+}
+
+// timeUnits are the expressions of the arrow time units.
+var timeUnits = map[arrow.TimeUnit]string{
+	arrow.Second:      "arrow.Second",
+	arrow.Millisecond: "arrow.Millisecond",
+	arrow.Microsecond: "arrow.Microsecond",
+	arrow.Nanosecond:  "arrow.Nanosecond",
+}
+
+// durations are the time.Duration of the arrow time units.
+var durations = map[arrow.TimeUnit]string{
+	arrow.Second:      "time.Second",
+	arrow.Millisecond: "time.Millisecond",
+	arrow.Microsecond: "time.Microsecond",
+	arrow.Nanosecond:  "time.Nanosecond",
+}
+
+// builders are the builders and data types of the basic types.
+var builders = map[string]string{
+	"bool":    "Boolean",
+	"int8":    "Int8",
+	"int16":   "Int16",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"int":     "Int64",
+	"uint8":   "Uint8",
+	"uint16":  "Uint16",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"uint":    "Uint64",
+	"uintptr": "Uint64",
+	"float32": "Float32",
+	"float64": "Float64",
+}
+
+// dateName is the name of the date type dt in arrow package.
+func dateName(dt arrow.DataType) string {
+	if dt.ID() == arrow.DATE32 {
+		return "Date32"
+	}
+
+	return "Date64"
+}
+
+// DataType is the expression of the arrow data type of the column.
+func (f Field) DataType() string {
+	o := f.options
+	switch {
+	case f.leaf == leafTime && o.Date != nil:
+		return "arrow.FixedWidthTypes." + dateName(o.Date)
+	case f.leaf == leafTime:
+		return fmt.Sprintf("&arrow.TimestampType{Unit: %s, TimeZone: %q}", timeUnits[o.Unit], o.TimeZone)
+	case f.leaf == leafDuration:
+		return fmt.Sprintf("&arrow.DurationType{Unit: %s}", timeUnits[o.Unit])
+	case o.Dictionary && f.leaf == leafString:
+		return "&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}"
+	case o.Dictionary:
+		return "&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.Binary}"
+	case o.Decimal:
+		return fmt.Sprintf("&arrow.Decimal128Type{Precision: %d, Scale: %d}", o.Precision, o.Scale)
+	case f.leaf == leafString:
+		return "arrow.BinaryTypes.String"
+	case f.leaf == leafBytes:
+		return "arrow.BinaryTypes.Binary"
+	case f.leaf == leafBool:
+		return "arrow.FixedWidthTypes.Boolean"
+	default:
+		return "arrow.PrimitiveTypes." + builders[f.basic]
+	}
+}
+
+// columnError is the expression of the error of the column with format, whose arguments are the column name and args.
+func (f Field) columnError(format string, args ...string) string {
+	return "fmt.Errorf(" + strconv.Quote(format) + ", " + strings.Join(append([]string{strconv.Quote(f.Column)}, args...), ", ") + ")"
+}
+
+// MissingError is the error of the missing column.
+func (f Field) MissingError() string {
+	return f.columnError("missing column %q for field " + f.Desc)
+}
+
+// DecodeError is the error of the column that cannot be decoded, wrapping err.
+func (f Field) DecodeError() string {
+	return f.columnError("cannot decode column %q into field "+f.Desc+": %w", "err")
+}
+
+// Nullable indicates if the column can contain nulls.
+func (f Field) Nullable() bool {
+	return f.wrapper != wrapNone || f.leaf == leafBytes
+}
+
+// Builder is the type of the builder of the column.
+func (f Field) Builder() string {
+	o := f.options
+	switch {
+	case f.leaf == leafTime && o.Date != nil:
+		return "*array." + dateName(o.Date) + "Builder"
+	case f.leaf == leafTime:
+		return "*array.TimestampBuilder"
+	case f.leaf == leafDuration:
+		return "*array.DurationBuilder"
+	case o.Dictionary:
+		return "*array.BinaryDictionaryBuilder"
+	case o.Decimal:
+		return "*array.Decimal128Builder"
+	case f.leaf == leafString:
+		return "*array.StringBuilder"
+	case f.leaf == leafBytes:
+		return "*array.BinaryBuilder"
+	default:
+		return "*array." + builders[f.basic] + "Builder"
+	}
+}
+
+// convert is the expression converting x to the basic type t, which is x itself if it is already of type t.
+func (f Field) convert(t, x string) string {
+	if f.goType == t {
+		return x
+	}
+
+	return t + "(" + x + ")"
+}
+
+// encodeError is the statement returning the error of encoding the field with format and args.
+func (f Field) encodeError(format string, args ...string) string {
+	return "return nil, fmt.Errorf(" + strconv.Quote("cannot encode row %d field "+f.Desc+": "+format) +
+		", " + strings.Join(append([]string{"i"}, args...), ", ") + ")"
+}
+
+// overflowError is the statement returning the [anyarrow.OverflowError] of x overflowing the builder of the column.
+func (f Field) overflowError(x string) string {
+	return f.encodeError("%w", "&anyarrow.OverflowError{DataType: "+f.Var+"Builder.Type(), GoType: reflect.TypeOf("+x+"), Index: i, Value: "+x+"}")
+}
+
+// encodeValue is the statement appending x, which is not null, to the builder of the column.
+func (f Field) encodeValue(x string) string {
+	b := f.Var + "Builder"
+	o := f.options
+	switch {
+	case f.leaf == leafTime && o.Date != nil:
+		return b + ".Append(arrow." + dateName(o.Date) + "FromTime(" + x + "))"
+	case f.leaf == leafTime:
+		return "ts, err := arrow.TimestampFromTime(" + x + ", " + timeUnits[o.Unit] + ")\n" +
+			"if err != nil {\n" + f.encodeError("%w", "err") + "\n}\n" +
+			b + ".Append(ts)"
+	case f.leaf == leafDuration && o.Unit == arrow.Nanosecond:
+		return b + ".Append(arrow.Duration(" + x + "))"
+	case f.leaf == leafDuration:
+		return b + ".Append(arrow.Duration(" + x + " / " + durations[o.Unit] + "))"
+	case o.Dictionary && f.leaf == leafString:
+		return "if err := " + b + ".AppendString(" + f.convert("string", x) + "); err != nil {\n" + f.encodeError("%w", "err") + "\n}"
+	case o.Dictionary:
+		return "if err := " + b + ".Append(" + f.convert("[]byte", x) + "); err != nil {\n" + f.encodeError("%w", "err") + "\n}"
+	case o.Decimal && f.leaf == leafFloat:
+		return fmt.Sprintf("n, err := decimal128.FromFloat64(%s, %d, %d)\n", f.convert("float64", x), o.Precision, o.Scale) +
+			"if err != nil {\n" + f.overflowError(x) + "\n}\n" +
+			b + ".Append(n)"
+	case o.Decimal:
+		from, t := "FromI64", "int64"
+		if f.leaf == leafUint {
+			from, t = "FromU64", "uint64"
+		}
+		return fmt.Sprintf("n := decimal128.%s(%s).IncreaseScaleBy(%d)\n", from, f.convert(t, x), o.Scale) +
+			fmt.Sprintf("if !n.FitsInPrecision(%d) {\n", o.Precision) +
+			f.overflowError(x) + "\n}\n" +
+			b + ".Append(n)"
+	case f.leaf == leafString:
+		return b + ".Append(" + f.convert("string", x) + ")"
+	case f.leaf == leafBytes:
+		return b + ".Append(" + f.convert("[]byte", x) + ")"
+	default:
+		basic := f.basic
+		switch basic {
+		case "int":
+			basic = "int64"
+		case "uint", "uintptr":
+			basic = "uint64"
+		}
+		return b + ".Append(" + f.convert(basic, x) + ")"
+	}
+}
+
+// Encode is the statement appending the field of rows[i] to the builder of the column.
+func (f Field) Encode() string {
+	x := "rows[i]." + f.Path
+	b := f.Var + "Builder"
+	switch {
+	case f.wrapper == wrapPointer:
+		return "if " + x + " == nil {\n" + b + ".AppendNull()\n} else {\n" + f.encodeValue("*"+x) + "\n}"
+	case f.wrapper == wrapNullable:
+		return "if !" + x + ".Valid {\n" + b + ".AppendNull()\n} else {\n" + f.encodeValue(x+".Value") + "\n}"
+	case f.leaf == leafBytes:
+		return "if " + x + " == nil {\n" + b + ".AppendNull()\n} else {\n" + f.encodeValue(x) + "\n}"
+	default:
+		return f.encodeValue(x)
+	}
+}
+
+// generator collects the structs and the imports of the generated file.
+type generator struct {
+	pkg *types.Package
+	// imports are the paths of the packages used by the generated code besides the ones always used.
+	imports map[string]bool
+	vars    map[string]bool
+}
+
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = true
+
+	return p.Name()
+}
+
+// Imports are the imported packages, sorted by path.
+func (g *generator) Imports() []string {
+	r := []string{
+		"errors",
+		"fmt",
+		"slices",
+		anyarrowPath,
+		"github.com/apache/arrow/go/v15/arrow",
+		"github.com/apache/arrow/go/v15/arrow/array",
+		"github.com/apache/arrow/go/v15/arrow/memory",
+	}
+	for path := range g.imports {
+		if !slices.Contains(r, path) {
+			r = append(r, path)
+		}
+	}
+	slices.Sort(r)
+
+	return r
+}
+
+// newStruct collects the fields of the named struct type.
+func (g *generator) newStruct(name string) (Struct, error) {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return Struct{}, fmt.Errorf("type %s is not found in package %s", name, g.pkg.Path())
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return Struct{}, fmt.Errorf("type %s is not a struct", name)
+	}
+
+	s := Struct{Name: name, exported: obj.Exported()}
+	g.vars = map[string]bool{}
+	var errs []string
+	g.collectFields(&s, st, "", &errs)
+	if len(s.Fields) == 0 && len(errs) == 0 {
+		errs = append(errs, "no field is mapped to arrow columns")
+	}
+	if len(errs) > 0 {
+		return s, fmt.Errorf("cannot generate codec for %s:\n%s", name, strings.Join(errs, "\n"))
+	}
+
+	return s, nil
+}
+
+// collectFields adds the fields of st to s, following the rules of the reflection based codecs.
+func (g *generator) collectFields(s *Struct, st *types.Struct, prefix string, errs *[]string) {
+	for i := range st.NumFields() {
+		v := st.Field(i)
+		tagValue, hasTag := reflect.StructTag(st.Tag(i)).Lookup(tag.Key)
+		if tagValue == "-" {
+			continue
+		}
+
+		if v.Embedded() && !hasTag {
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+				if v.Exported() {
+					*errs = append(*errs, fmt.Sprintf("embedded pointer %s is not supported", prefix+v.Name()))
+				}
+				continue
+			}
+			if est, ok := v.Type().Underlying().(*types.Struct); ok {
+				g.collectFields(s, est, prefix+v.Name()+".", errs)
+				continue
+			}
+		}
+		if !v.Exported() {
+			continue
+		}
+
+		name, options := tag.Parse(tagValue)
+		if name == "" {
+			name = v.Name()
+		}
+		f, err := g.newField(v.Type(), options)
+		if err != nil {
+			*errs = append(*errs, fmt.Sprintf("field %s: %v", prefix+v.Name(), err))
+			continue
+		}
+		switch {
+		case f.leaf == leafTime || f.leaf == leafDuration:
+			g.imports["time"] = true
+		case f.leaf == leafString:
+			g.imports["strings"] = true
+		case f.options.Decimal:
+			g.imports["github.com/apache/arrow/go/v15/arrow/decimal128"] = true
+			g.imports["reflect"] = true
+		}
+		f.Column = name
+		f.Path = prefix + v.Name()
+		f.Desc = g.pkg.Name() + "." + s.Name + "." + f.Path
+		f.Var = g.newVar(f.Path)
+		s.Fields = append(s.Fields, f)
+	}
+}
+
+// newVar returns an unused identifier for the field with path.
+func (g *generator) newVar(path string) string {
+	base := strings.ReplaceAll(path, ".", "")
+	base = string(unicode.ToLower(rune(base[0]))) + base[1:]
+	v := base
+	for i := 1; token.IsKeyword(v) || g.vars[v]; i++ {
+		v = base + strconv.Itoa(i)
+	}
+	g.vars[v] = true
+
+	return v
+}
+
+func isNamed(t types.Type, path, name string) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Origin().Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == path && obj.Name() == name
+}
+
+// newField maps go type t of a field to arrow.
+func (g *generator) newField(t types.Type, options []string) (Field, error) {
+	o, err := tag.ParseOptions(options)
+	if err != nil {
+		return Field{}, err
+	}
+	f := Field{options: o}
+
+	switch {
+	case isNamed(t, anyarrowPath, "Nullable"):
+		f.wrapper = wrapNullable
+		t = t.(*types.Named).TypeArgs().At(0)
+	default:
+		if p, ok := t.(*types.Pointer); ok {
+			f.wrapper = wrapPointer
+			t = p.Elem()
+		}
+	}
+	f.goType = types.TypeString(t, g.qualifier)
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		f.basic = u.Name()
+		if isNamed(t, "time", "Duration") {
+			f.leaf = leafDuration
+			break
+		}
+		info := u.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			f.leaf = leafBool
+		case info&types.IsUnsigned != 0:
+			f.leaf = leafUint
+		case info&types.IsInteger != 0:
+			f.leaf = leafInt
+		case info&types.IsFloat != 0:
+			f.leaf = leafFloat
+		case info&types.IsString != 0:
+			f.leaf = leafString
+		default:
+			return f, fmt.Errorf("unsupported type %s", f.goType)
+		}
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); !ok || b.Kind() != types.Byte {
+			return f, fmt.Errorf("unsupported type %s, use anyarrow.Marshal and anyarrow.Unmarshal for lists", f.goType)
+		}
+		f.leaf = leafBytes
+	case *types.Struct:
+		if !isNamed(t, "time", "Time") {
+			return f, fmt.Errorf("unsupported type %s, use anyarrow.Marshal and anyarrow.Unmarshal for structs", f.goType)
+		}
+		f.leaf = leafTime
+	default:
+		return f, fmt.Errorf("unsupported type %s", f.goType)
+	}
+
+	switch {
+	case o.Dictionary && f.leaf != leafString && f.leaf != leafBytes:
+		return f, fmt.Errorf("cannot dictionary encode %s", f.goType)
+	case o.Decimal && f.leaf != leafInt && f.leaf != leafUint && f.leaf != leafFloat:
+		return f, fmt.Errorf("cannot encode %s as decimal", f.goType)
+	}
+
+	return f, nil
+}
+
+// loadPackage type checks the package in dir, excluding the test files and the output file of the previous run.
+//
+// Type errors are ignored, since they may be caused by the missing generated code.
+func loadPackage(dir, output string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(bp.ImportPath, fset, files, nil)
+
+	return pkg, nil
+}
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated names of the struct types, required")
+	output := flag.String("output", "", "output file name, default to <first type>_arrow.go in lower case")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: codecgen -type T[,T...] [-output file] [package directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(strings.TrimSpace(strings.Split(*typeNames, ",")[0])) + "_arrow.go"
+	}
+
+	pkg, err := loadPackage(dir, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	g := &generator{pkg: pkg, imports: map[string]bool{}}
+	var structs []Struct
+	for _, name := range strings.Split(*typeNames, ",") {
+		s, err := g.newStruct(strings.TrimSpace(name))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		structs = append(structs, s)
+	}
+
+	tmpl := must(template.New("codec_go").Parse(codecGoTplStr))
+	var b bytes.Buffer
+	orpanic(tmpl.Execute(&b, map[string]any{
+		"Package": pkg.Name(),
+		"Imports": g.Imports(),
+		"Structs": structs,
+	}))
+
+	orpanic(os.WriteFile(filepath.Join(dir, *output), must(format.Source(b.Bytes(), format.Options{
+		LangVersion: "v1.23",
+	})), 0o660))
+}
@@ -2,9 +2,14 @@ package anyarrow
 
 import (
     "fmt"
+    "iter"
+    "reflect"
+    "slices"
+    "sync/atomic"
 
 	"github.com/apache/arrow/go/v15/arrow"
 	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/bitutil"
 )
 
 type arrowArray struct {
@@ -12,74 +17,522 @@ type arrowArray struct {
 }
 {{range .}}
 // {{.GoName}} provides convenient access to [arrow.Array]'s element as {{.GoType}}
-type {{.GoName}} struct {
+type {{.GoName}} = {{.GoName}}Of[{{.GoType}}]
+
+// {{.GoName}}Of provides convenient access to [arrow.Array]'s element as T,
+// which can be any type whose underlying type is {{.GoType}}.
+type {{.GoName}}Of[T ~{{.GoType}}] struct {
 	arrowArray
 
 	direct *array.{{.ArrowType}}
-    getFunc func(int) {{.GoType}}
+    // values of the array, or values of the dictionary if the array is a dictionary.
+    values buffer
+    // indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+    indices buffer
+    // decoded values of the dictionary, only set when [WithDecodedDictionary] is used.
+    decoded []T
+    // nulls is the number of null elements, only set for dictionaries with null values.
+    nulls int
+    // dictionary is the data of the dictionary, used to reuse decoded when the dictionary is unchanged.
+    dictionary arrow.ArrayData
+
+    refCount int64
+
+    opts options
 }
 
 var _ arrow.Array = (*{{.GoName}})(nil)
 
 // IsDirect indicates if the underlying [arrow.Array] is an [array.{{.ArrowType}}].
-func (a *{{.GoName}}) IsDirect() bool {
+func (a *{{.GoName}}Of[T]) IsDirect() bool {
     return a.direct != nil
 }
 
-// Value retrieves the element at index i as {{.GoType}}
-func (a *{{.GoName}}) Value(i int) {{.GoType}} {
+// Explain describes how the elements of the underlying [arrow.Array] are converted to T, see [CanConvert],
+// and how they are read.
+func (a *{{.GoName}}Of[T]) Explain() string {
+    c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+    switch {
+    case a.direct != nil:
+        return c.String() + ", read directly from array.{{.ArrowType}}"
+    case a.decoded != nil:
+        return c.String() + ", decoded once into a slice"
+    default:
+        return c.String() + ", converted on every read"
+    }
+}
+
+// Value retrieves the element at index i as T
+func (a *{{.GoName}}Of[T]) Value(i int) T {
     if a.direct != nil {
-        return a.direct.Value(i)
-    } else if a.getFunc != nil {
-        return a.getFunc(i)
-    } else {
+        return {{.DirectKind.Value}}
+    }
+    if a.indices.kind != kindUnknown {
+        return a.DictValue(a.indices.index(i))
+    }
+
+    return a.value(i)
+}
+
+// ValueOk retrieves the element at index i as T, and whether it is valid.
+func (a *{{.GoName}}Of[T]) ValueOk(i int) (T, bool) {
+    if !a.IsValid(i) {
+        var zero T
+        return zero, false
+    }
+
+    return a.Value(i), true
+}
+
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *{{.GoName}}Of[T]) IsValid(i int) bool {
+    if !a.Array.IsValid(i) {
+        return false
+    }
+    if a.indices.kind != kindUnknown {
+        return a.values.isValid(a.indices.index(i))
+    }
+
+    return a.values.kind != kindNull
+}
+
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *{{.GoName}}Of[T]) IsNull(i int) bool {
+    return !a.IsValid(i)
+}
+
+// NullN returns the number of null elements, see IsValid.
+func (a *{{.GoName}}Of[T]) NullN() int {
+    if a.values.kind == kindNull {
+        return a.Len()
+    }
+    if a.indices.kind != kindUnknown && a.values.valid != nil {
+        return a.nulls
+    }
+
+    return a.Array.NullN()
+}
+
+// value retrieves the element at index i of the values.
+func (a *{{.GoName}}Of[T]) value(i int) T {
+    switch a.values.kind {
+{{range .Kinds}}    case kind{{.Name}}:
+        return {{.Value}}
+{{end -}}
+    default:
         panic("uninitialized accessor for go type {{.GoType}}")
     }
 }
 
-// New{{.GoName}} wraps the provided [arrow.Array].
-func New{{.GoName}}(a arrow.Array) (*{{.GoName}}, error) {
-    if direct, ok := a.(*array.{{.ArrowType}}); ok {
-        return &{{.GoName}}{direct: direct, arrowArray: arrowArray{Array: a}}, nil
+// fits checks if the i-th value of the values converts to T exactly.
+func (a *{{.GoName}}Of[T]) fits(i int) bool {
+    switch a.values.kind {
+{{range .Kinds}}    case kind{{.Name}}:
+        return {{.Fits}}
+{{end -}}
+    default:
+        panic("uninitialized accessor for go type {{.GoType}}")
+    }
+}
+
+// Validate checks if every valid element of the underlying [arrow.Array] converts to T exactly,
+// and returns an [*OverflowError] of the first element that doesn't.
+//
+// Only narrowing conversions reported by [CanConvert] can lose information, so the elements are not scanned otherwise.
+// Integers fit if they are in the range of T, floats fit integers if they have no fractional part and are in the range,
+// and fit floats if they are recovered from T. Decimals fit if they are recovered from T.
+// For dictionaries, each dictionary value is checked once.
+func (a *{{.GoName}}Of[T]) Validate() error {
+    c, _ := CanConvert(a.DataType(), reflect.TypeFor[T]())
+    if c.Kind != ConversionNarrowing {
+        return nil
+    }
+
+    var dictionaryFits []bool
+    if a.indices.kind != kindUnknown {
+        dictionaryFits = make([]bool, a.values.len())
+        for j := range dictionaryFits {
+            dictionaryFits[j] = !a.values.isValid(j) || a.fits(j)
+        }
+    }
+    for i := range a.Len() {
+        if a.Array.NullN() > 0 && a.Array.IsNull(i) {
+            continue
+        }
+        if dictionaryFits != nil && dictionaryFits[a.indices.index(i)] || dictionaryFits == nil && a.fits(i) {
+            continue
+        }
+        return &OverflowError{DataType: a.DataType(), GoType: reflect.TypeFor[T](), Index: i, Value: a.Array.ValueStr(i)}
+    }
+
+    return nil
+}
+
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *{{.GoName}}Of[T]) IsDictionary() bool {
+    return a.indices.kind != kindUnknown
+}
+
+// Index returns the index into the dictionary of the element at index i.
+//
+// For arrays that are not dictionaries, each element is its own dictionary value and i is returned.
+func (a *{{.GoName}}Of[T]) Index(i int) int {
+    if a.indices.kind != kindUnknown {
+        return a.indices.index(i)
+    }
+
+    return i
+}
+
+// DictionaryLen returns the number of values in the dictionary.
+//
+// For arrays that are not dictionaries, this is the length of the array.
+func (a *{{.GoName}}Of[T]) DictionaryLen() int {
+    return a.values.len()
+}
+
+// DictValue retrieves the value at index j of the dictionary as T.
+//
+// For arrays that are not dictionaries, this is the same as Value.
+func (a *{{.GoName}}Of[T]) DictValue(j int) T {
+    if a.decoded != nil {
+        return a.decoded[j]
+    }
+
+    return a.value(j)
+}
+
+// CopyTo copies the elements into dst, and returns the number of elements copied,
+// which is the minimum of len(dst) and a.Len().
+//
+// Null slots are copied as whatever is stored in the array,
+// except null indices of dictionaries, which are copied as the zero value.
+func (a *{{.GoName}}Of[T]) CopyTo(dst []T) int {
+    return a.copyFrom(dst, 0)
+}
+
+// copyFrom copies the elements starting from index start into dst, and returns the number of elements copied.
+func (a *{{.GoName}}Of[T]) copyFrom(dst []T, start int) int {
+    n := min(len(dst), a.Len()-start)
+    dst = dst[:n]
+    if a.indices.kind != kindUnknown && a.Array.NullN() > 0 {
+        // the indices of null slots are undefined and must not be looked up.
+        for i := range dst {
+            if a.Array.IsNull(start + i) {
+                dst[i] = *new(T)
+            } else {
+                dst[i] = a.DictValue(a.indices.index(start + i))
+            }
+        }
+
+        return n
+    }
+    if a.indices.kind != kindUnknown && a.decoded != nil {
+        switch a.indices.kind {
+        case kindUint8:
+            return gather(dst, a.indices.u8[start:], a.decoded)
+        case kindUint16:
+            return gather(dst, a.indices.u16[start:], a.decoded)
+        case kindUint32:
+            return gather(dst, a.indices.u32[start:], a.decoded)
+        case kindUint64:
+            return gather(dst, a.indices.u64[start:], a.decoded)
+        }
+    }
+    if a.indices.kind != kindUnknown {
+        for i := range dst {
+            dst[i] = a.value(a.indices.index(start + i))
+        }
+
+        return n
     }
 
-    r := &{{.GoName}}{}
-    {{ $gotype := .GoType}}
-    switch v:= a.(type) {
-{{range .ArrowTypes}}case *array.{{.Array}}:
-        r.arrowArray.Array = a
-        r.getFunc =  func(i int) {{$gotype}} {
-                return {{$gotype}}(v.Value(i))
+    switch a.values.kind {
+{{range .Kinds}}    case kind{{.Name}}:
+{{- if .Field}}
+        return convertSlice(dst, a.values.{{.Field}}[start:])
+{{- else if eq .Name "Null"}}
+        clear(dst)
+
+        return n
+{{- else}}
+        for i := range dst {
+            dst[i] = a.value(start + i)
         }
-        return r, nil
 
+        return n
+{{- end}}
+{{end -}}
+    default:
+        panic("uninitialized accessor for go type {{.GoType}}")
+    }
+}
+
+// AppendValues appends all the elements to dst and returns the extended slice.
+//
+// Null slots are appended as whatever is stored in the array, see CopyTo.
+func (a *{{.GoName}}Of[T]) AppendValues(dst []T) []T {
+    n := len(dst)
+    dst = slices.Grow(dst, a.Len())[:n+a.Len()]
+    a.CopyTo(dst[n:])
+
+    return dst
+}
+
+// Values returns all the elements as a slice.
+{{- if .ZeroCopyKinds}}
+//
+// When the array is direct, or the conversion to {{.GoType}} keeps the bit representation of the elements unchanged,
+// the returned slice shares the memory of the array and must not be modified.
+// Otherwise a newly allocated slice is returned.
+{{- else}}
+// The returned slice is always newly allocated.
+{{- end}}
+func (a *{{.GoName}}Of[T]) Values() []T {
+    if values, ok := a.sharedValues(); ok {
+        return values
+    }
+
+    return a.AppendValues(make([]T, 0, a.Len()))
+}
+
+// sharedValues returns the elements as a slice sharing the memory of the array, if possible.
+func (a *{{.GoName}}Of[T]) sharedValues() ([]T, bool) {
+{{- if .ZeroCopyKinds}}
+    if a.indices.kind == kindUnknown {
+        switch a.values.kind {
+{{range .ZeroCopyKinds}}        case kind{{.Name}}:
+            return reinterpretSlice[T](a.values.{{.Field}}), true
 {{end -}}
-    case *array.Dictionary:
-        dt, ok := v.DataType().(*arrow.DictionaryType)
+        }
+    }
+{{end}}
+    return nil, false
+}
+
+// All returns an iterator over the indices and elements of the array.
+//
+// Null slots are yielded as whatever is stored in the array,
+// except null indices of dictionaries, which are yielded as the zero value.
+func (a *{{.GoName}}Of[T]) All() iter.Seq2[int, T] {
+    return func(yield func(int, T) bool) {
+        for i := range a.Len() {
+            var v T
+            // the indices of null slots are undefined and must not be looked up.
+            if a.indices.kind == kindUnknown || a.Array.IsValid(i) {
+                v = a.Value(i)
+            }
+            if !yield(i, v) {
+                return
+            }
+        }
+    }
+}
+
+// AllNullable returns an iterator over the indices and elements of the array,
+// with null slots yielded as the zero [Nullable], which is invalid.
+func (a *{{.GoName}}Of[T]) AllNullable() iter.Seq2[int, Nullable[T]] {
+    return func(yield func(int, Nullable[T]) bool) {
+        for i := range a.Len() {
+            var v Nullable[T]
+            if a.IsValid(i) {
+                v = Nullable[T]{Value: a.Value(i), Valid: true}
+            }
+            if !yield(i, v) {
+                return
+            }
+        }
+    }
+}
+
+// Valid returns an iterator over the indices and elements of the array, skipping the null slots.
+func (a *{{.GoName}}Of[T]) Valid() iter.Seq2[int, T] {
+    return func(yield func(int, T) bool) {
+        for i := range a.Len() {
+            if a.IsValid(i) && !yield(i, a.Value(i)) {
+                return
+            }
+        }
+    }
+}
+
+// Chunks returns an iterator over the elements of the array in chunks of size elements,
+// except the last chunk, which may be shorter.
+// Each chunk is yielded with the index of its first element.
+//
+// The yielded chunk is only valid until the next iteration and must not be modified,
+// since it is either reused for the next chunk or shares the memory of the array.
+// Null slots are yielded as whatever is stored in the array.
+func (a *{{.GoName}}Of[T]) Chunks(size int) iter.Seq2[int, []T] {
+    if size <= 0 {
+        panic(fmt.Sprintf("chunk size %d is not positive", size))
+    }
+
+    return func(yield func(int, []T) bool) {
+        n := a.Len()
+        values, shared := a.sharedValues()
+        if !shared {
+            values = make([]T, min(size, n))
+        }
+        for start := 0; start < n; start += size {
+            var chunk []T
+            if shared {
+                chunk = values[start:min(start+size, n)]
+            } else {
+                chunk = values[:a.copyFrom(values, start)]
+            }
+            if !yield(start, chunk) {
+                return
+            }
+        }
+    }
+}
+
+// New{{.GoName}} wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] is returned if the type of the array cannot be read as {{.GoType}},
+// and an [*UnsupportedDictionaryError] or a [*DictionaryMismatchError] if the array is a dictionary that cannot be read.
+func New{{.GoName}}(a arrow.Array, opts ...Option) (*{{.GoName}}, error) {
+    return New{{.GoName}}Of[{{.GoType}}](a, opts...)
+}
+
+// New{{.GoName}}Of wraps the provided [arrow.Array], converting the elements to T.
+//
+// It accepts the same [arrow.Array]s as [New{{.GoName}}].
+// The [arrow.Array] is retained, and released when the accessor is released.
+func New{{.GoName}}Of[T ~{{.GoType}}](a arrow.Array, opts ...Option) (*{{.GoName}}Of[T], error) {
+    r := &{{.GoName}}Of[T]{opts: newOptions(opts), refCount: 1}
+    if err := r.bind(a); err != nil {
+        return nil, err
+    }
+    a.Retain()
+
+    return r, nil
+}
+
+// Retain increases the reference count of the accessor by 1.
+func (a *{{.GoName}}Of[T]) Retain() {
+    atomic.AddInt64(&a.refCount, 1)
+}
+
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *{{.GoName}}Of[T]) Release() {
+    if atomic.AddInt64(&a.refCount, -1) == 0 {
+        a.Array.Release()
+        *a = {{.GoName}}Of[T]{}
+    }
+}
+
+// Reset points the accessor to arr, as if the accessor was created by New{{.GoName}}Of with arr and the same options.
+//
+// arr is retained and the previous array is released.
+// The decoded dictionary is reused if arr has the same dictionary as the previous array,
+// so resetting the accessor doesn't allocate.
+// If an error is returned, the accessor is unchanged.
+func (a *{{.GoName}}Of[T]) Reset(arr arrow.Array) error {
+    prev := a.Array
+    if err := a.bind(arr); err != nil {
+        return err
+    }
+    arr.Retain()
+    if prev != nil {
+        prev.Release()
+    }
+
+    return nil
+}
+
+// Slice returns a new accessor of the elements in [i, j), with the same options as a.
+//
+// The returned accessor must be released, see [array.NewSlice].
+func (a *{{.GoName}}Of[T]) Slice(i, j int) *{{.GoName}}Of[T] {
+    r := &{{.GoName}}Of[T]{opts: a.opts, decoded: a.decoded, dictionary: a.dictionary, refCount: 1}
+    if err := r.bind(array.NewSlice(a.Array, int64(i), int64(j))); err != nil {
+        panic(err)
+    }
+
+    return r
+}
+
+// bind points the accessor to arr, and leaves the accessor unchanged if an error is returned.
+//
+// The decoded dictionary is reused if arr has the same dictionary as the previously bound array.
+func (a *{{.GoName}}Of[T]) bind(arr arrow.Array) error {
+    if a.opts.lossless {
+        if c, err := CanConvert(arr.DataType(), reflect.TypeFor[T]()); err == nil && c.Kind == ConversionNarrowing {
+            return &LossyConversionError{Conversion: c}
+        }
+    }
+
+    r := {{.GoName}}Of[T]{arrowArray: arrowArray{Array: arr}, opts: a.opts, refCount: a.refCount}
+
+    v, ok := arr.(*array.Dictionary)
+    if !ok {
+        values, ok := {{.LowerName}}Buffer(arr)
         if !ok {
-            return nil, fmt.Errorf("arrow dictionary's datatype is not dictionary")
+            return &UnsupportedTypeError{DataType: arr.DataType(), GoType: reflect.TypeFor[T]()}
         }
-        switch dt.ValueType.ID() {
-{{range .ArrowTypes}}case arrow.{{.ID}}:
-       		dictvalues, ok := v.Dictionary().(*array.{{.Array}})
-       		if !ok {
-       		    return nil, fmt.Errorf("cannot convert arrow dictionary's dictionary %s to type {{.Array}}", v.Dictionary().DataType().String())
-       		}
+        r.values = values
+        r.direct, _ = arr.(*array.{{.ArrowType}})
+        *a = r
 
-            r.arrowArray.Array = a
-       		r.getFunc = func(i int) {{$gotype}} {
-       		    return {{$gotype}}(dictvalues.Value(v.GetValueIndex(i)))
-       		}
+        return nil
+    }
 
-       		return r, nil
+    dt, ok := v.DataType().(*arrow.DictionaryType)
+    if !ok {
+        return &DictionaryMismatchError{DataType: v.DataType(), GoType: reflect.TypeFor[T]()}
+    }
+    if v.Dictionary().DataType().ID() != dt.ValueType.ID() {
+        return &DictionaryMismatchError{DataType: dt, ValueType: v.Dictionary().DataType(), GoType: reflect.TypeFor[T]()}
+    }
+    indices, ok := indexBuffer(v.Indices())
+    if !ok {
+        return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+    }
+    values, ok := {{.LowerName}}Buffer(v.Dictionary())
+    if !ok {
+        return &UnsupportedDictionaryError{DataType: dt, GoType: reflect.TypeFor[T]()}
+    }
+    values.setValidity(v.Dictionary())
+    r.values, r.indices, r.dictionary = values, indices, v.Data().Dictionary()
+    if r.opts.decodeDictionary && a.decoded != nil && a.dictionary == r.dictionary {
+        r.decoded = a.decoded
+    }
+    *a = r
 
-{{end -}}
-        default:
-            return nil, fmt.Errorf("cannot use %s dictionary for {{$gotype}}", dt.ValueType.String())
+    if a.opts.decodeDictionary && a.decoded == nil {
+        a.decoded = make([]T, a.values.len())
+        for j := range a.decoded {
+            a.decoded[j] = a.value(j)
+        }
+    }
+    if a.values.valid != nil {
+        for i := range arr.Len() {
+            if a.IsNull(i) {
+                a.nulls++
+            }
         }
+    }
+
+    return nil
+}
 
+// {{.LowerName}}Buffer creates the [buffer] for the arrays that can be converted to {{.GoType}}.
+func {{.LowerName}}Buffer(a arrow.Array) (buffer, bool) {
+    switch v := a.(type) {
+{{range .ArrowTypes}}    case *array.{{.Array}}:
+        return {{.Buffer}}, true
+{{end -}}
     default:
-        return nil, fmt.Errorf("cannot use %s for gotype {{$gotype}}", a.String())
+        return buffer{}, false
     }
 }
 {{end}}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	_ "embed"
 	"os"
+	"slices"
+	"strings"
 	"text/template"
 
 	"golang.org/x/text/cases"
@@ -31,10 +33,118 @@ type pair struct {
 	arrowtype string
 }
 
+// Kind is the physical layout of the values of arrow arrays, see kind in buffer.go.
+type Kind struct {
+	Name string
+	// Field is the slice field of buffer holding the values, empty for variable width kinds.
+	Field string
+	// Elem is the go type of the elements of Field.
+	Elem string
+	// BitIdentical indicates the elements have the same bit representation as the go type.
+	BitIdentical bool
+	// Float indicates the go type is a floating point number.
+	Float bool
+}
+
+// Value is the expression to read i-th value of the buffer a.values as T.
+func (k Kind) Value() string {
+	switch k.Name {
+	case "Binary":
+		return "T(a.values.data[a.values.offsets[i]:a.values.offsets[i+1]])"
+	case "LargeBinary":
+		return "T(a.values.data[a.values.largeOffsets[i]:a.values.largeOffsets[i+1]])"
+	case "Boolean":
+		return "fromBool[T](bitutil.BitIsSet(a.values.bits, a.values.bitOffset+i))"
+	case "Null":
+		return "*new(T)"
+	case "Decimal128", "Decimal256":
+		field := "d128"
+		if k.Name == "Decimal256" {
+			field = "d256"
+		}
+		if k.Float {
+			return "T(a.values." + field + "[i].ToFloat64(a.values.scale))"
+		}
+
+		return "T(" + strings.ToLower(k.Name) + "Int(a.values." + field + "[i], a.values.scale))"
+	default:
+		return "T(a.values." + k.Field + "[i])"
+	}
+}
+
+// Fits is the expression checking if the i-th value of the buffer a.values converts to T exactly.
+func (k Kind) Fits() string {
+	switch {
+	case k.Name == "Binary" || k.Name == "LargeBinary" || k.Name == "Boolean" || k.Name == "Null":
+		return "true"
+	case k.Name == "Decimal128" || k.Name == "Decimal256":
+		return strings.ToLower(k.Name) + "Fits[T](a.values.d" + k.Name[7:] + "[i], a.values.scale)"
+	case strings.HasPrefix(k.Elem, "int"):
+		return "intFits[T](int64(a.values." + k.Field + "[i]))"
+	case strings.HasPrefix(k.Elem, "uint"):
+		return "uintFits[T](uint64(a.values." + k.Field + "[i]))"
+	default:
+		return "floatFits[T](float64(a.values." + k.Field + "[i]))"
+	}
+}
+
+var kinds = map[string]Kind{
+	"Int8":        {Name: "Int8", Field: "i8", Elem: "int8"},
+	"Int16":       {Name: "Int16", Field: "i16", Elem: "int16"},
+	"Int32":       {Name: "Int32", Field: "i32", Elem: "int32"},
+	"Int64":       {Name: "Int64", Field: "i64", Elem: "int64"},
+	"Uint8":       {Name: "Uint8", Field: "u8", Elem: "uint8"},
+	"Uint16":      {Name: "Uint16", Field: "u16", Elem: "uint16"},
+	"Uint32":      {Name: "Uint32", Field: "u32", Elem: "uint32"},
+	"Uint64":      {Name: "Uint64", Field: "u64", Elem: "uint64"},
+	"Float32":     {Name: "Float32", Field: "f32", Elem: "float32"},
+	"Float64":     {Name: "Float64", Field: "f64", Elem: "float64"},
+	"Binary":      {Name: "Binary"},
+	"LargeBinary": {Name: "LargeBinary"},
+	"Boolean":     {Name: "Boolean"},
+	"Decimal128":  {Name: "Decimal128"},
+	"Decimal256":  {Name: "Decimal256"},
+	"Null":        {Name: "Null"},
+}
+
 type ArrowType struct {
 	Array string
-	ID    string
+	Kind  Kind
 }
+
+// Buffer is the expression to create the buffer from array v.
+func (a ArrowType) Buffer() string {
+	switch a.Kind.Name {
+	case "Binary":
+		return "binaryBuffer(v)"
+	case "LargeBinary":
+		return "largeBinaryBuffer(v)"
+	case "Boolean":
+		return "booleanBuffer(v)"
+	case "Decimal128":
+		return "decimal128Buffer(v)"
+	case "Decimal256":
+		return "decimal256Buffer(v)"
+	case "Null":
+		return "nullBuffer(v)"
+	}
+
+	values := "v." + a.Array + "Values()"
+	if a.Array != a.Kind.Name {
+		values = "reinterpretSlice[" + a.Kind.Elem + "](" + values + ")"
+	}
+
+	return "buffer{kind: kind" + a.Kind.Name + ", " + a.Kind.Field + ": " + values + "}"
+}
+
+func newArrowType(gotype, array, kind string) ArrowType {
+	k := kinds[kind]
+	k.BitIdentical = k.Field != "" && isBitIdentical(gotype, k.Elem)
+	k.Float = gotype == "float32" || gotype == "float64"
+
+	return ArrowType{Array: array, Kind: k}
+}
+
 type genValue struct {
 	t          pair
 	ArrowTypes []ArrowType
@@ -46,6 +156,11 @@ func (p genValue) GoName() string {
 	return titleCaser.String(p.t.gotype)
 }
 
+// LowerName is the GoName with first letter in lower case.
+func (p genValue) LowerName() string {
+	return p.t.gotype
+}
+
 func (p genValue) GoType() string {
 	return p.t.gotype
 }
@@ -54,12 +169,101 @@ func (p genValue) ArrowType() string {
 	return p.t.arrowtype
 }
 
+// DirectKind is the kind of the arrow type that the go type can be directly read from.
+func (p genValue) DirectKind() Kind {
+	for _, a := range p.ArrowTypes {
+		if a.Array == p.t.arrowtype {
+			return a.Kind
+		}
+	}
+
+	panic("no direct arrow type for " + p.t.gotype)
+}
+
+// Kinds are the unique kinds of the arrow types.
+func (p genValue) Kinds() []Kind {
+	var r []Kind
+	for _, a := range p.ArrowTypes {
+		if !slices.Contains(r, a.Kind) {
+			r = append(r, a.Kind)
+		}
+	}
+
+	return r
+}
+
+// ZeroCopyKinds are the kinds whose values can be returned without copying.
+func (p genValue) ZeroCopyKinds() []Kind {
+	var r []Kind
+	for _, k := range p.Kinds() {
+		if k.BitIdentical {
+			r = append(r, k)
+		}
+	}
+
+	return r
+}
+
+// kindOf are the kinds of the numeric arrow arrays.
+var kindOf = map[string]string{
+	"Int8":      "Int8",
+	"Int16":     "Int16",
+	"Int32":     "Int32",
+	"Int64":     "Int64",
+	"Uint8":     "Uint8",
+	"Uint16":    "Uint16",
+	"Uint32":    "Uint32",
+	"Uint64":    "Uint64",
+	"Timestamp": "Int64",
+	"Duration":  "Int64",
+	"Float32":   "Float32",
+	"Float64":   "Float64",
+	"Date32":    "Int32",
+	"Date64":    "Int64",
+	"Time32":    "Int32",
+	"Time64":    "Int64",
+
+	"Boolean":    "Boolean",
+	"Decimal128": "Decimal128",
+	"Decimal256": "Decimal256",
+	"Null":       "Null",
+}
+
+var intSizes = map[string]int{
+	"byte":   1,
+	"int8":   1,
+	"uint8":  1,
+	"int16":  2,
+	"uint16": 2,
+	"int32":  4,
+	"uint32": 4,
+	"int64":  8,
+	"uint64": 8,
+}
+
+// isBitIdentical checks if converting elem to gotype keeps the bit representation unchanged.
+func isBitIdentical(gotype, elem string) bool {
+	if gotype == elem {
+		return true
+	}
+	gosize, ok := intSizes[gotype]
+	if !ok {
+		return false
+	}
+	elemsize, ok := intSizes[elem]
+
+	return ok && gosize == elemsize
+}
+
 func main() {
 	allArrowTypes := []string{
 		"Int8", "Int16", "Int32", "Int64",
 		"Uint8", "Uint16", "Uint32", "Uint64",
 		"Timestamp", "Duration",
 		"Float32", "Float64", "Date32", "Date64",
+		"Time32", "Time64",
+		"Boolean", "Decimal128", "Decimal256",
+		"Null",
 	}
 
 	allpairs := []pair{
@@ -82,16 +286,12 @@ func main() {
 
 	genvalues := []genValue{}
 
-	upperCaser := cases.Upper(language.AmericanEnglish)
 	for _, p := range allpairs {
 		v := genValue{
 			t: p,
 		}
 		for _, a := range allArrowTypes {
-			v.ArrowTypes = append(v.ArrowTypes, ArrowType{
-				Array: a,
-				ID:    upperCaser.String(a),
-			})
+			v.ArrowTypes = append(v.ArrowTypes, newArrowType(p.gotype, a, kindOf[a]))
 		}
 		genvalues = append(genvalues, v)
 	}
@@ -99,16 +299,17 @@ func main() {
 	genvalues = append(genvalues, genValue{
 		t: pair{"string", "String"},
 		ArrowTypes: []ArrowType{
-			{Array: "String", ID: "STRING"},
-			{Array: "Binary", ID: "BINARY"},
-			{Array: "LargeString", ID: "LARGE_STRING"},
-			{Array: "LargeBinary", ID: "LARGE_BINARY"},
+			newArrowType("string", "String", "Binary"),
+			newArrowType("string", "Binary", "Binary"),
+			newArrowType("string", "LargeString", "LargeBinary"),
+			newArrowType("string", "LargeBinary", "LargeBinary"),
+			newArrowType("string", "Null", "Null"),
 		},
 	})
 	orpanic(tmpl.Execute(&b, genvalues))
 
 	orpanic(os.WriteFile("array.go", must(format.Source(b.Bytes(), format.Options{
-		LangVersion: "v1.21.6",
+		LangVersion: "v1.23",
 		ModulePath:  "github.com/fardream/anyarrow",
 	})), 0o660))
 }
@@ -0,0 +1,297 @@
+// Command structgen generates go struct definitions with `arrow` tags from the schema of an arrow IPC file,
+// which can be decoded by [anyarrow.Unmarshal] and encoded by [anyarrow.Marshal].
+//
+// The input can be an IPC file (.arrow), an IPC stream (.arrows), or a schema serialized as an IPC stream without records.
+//
+//	structgen -type Trade -package trade trades.arrow > trade.go
+//
+// Columns are mapped to go types as
+//   - booleans, integers, floats and strings to the go types of the same size.
+//   - binaries to []byte.
+//   - timestamps, date32 and date64 to time.Time, and durations to time.Duration.
+//   - time32 and time64 to int32 and int64.
+//   - decimals to float64, tagged with their precision and scale unless [anyarrow.Marshal] cannot encode them,
+//     which is the case for precisions over 38 and negative scales.
+//   - dictionaries to the type of their values.
+//   - lists to slices, and structs to nested struct types named after the parent type and the field.
+//
+// Nullable columns are mapped to pointers, or to [anyarrow.Nullable] with -nullable=nullable,
+// except for binaries and lists, whose nil slices are nulls.
+// Columns of other types are listed as comments.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fardream/anyarrow/internal/tag"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/ipc"
+	"mvdan.cc/gofumpt/format"
+)
+
+// readSchema reads the schema of the IPC file or stream in r.
+func readSchema(r ipc.ReadAtSeeker) (*arrow.Schema, error) {
+	if f, err := ipc.NewFileReader(r); err == nil {
+		defer f.Close()
+		return f.Schema(), nil
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	s, err := ipc.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not an arrow IPC file or stream: %w", err)
+	}
+	defer s.Release()
+
+	return s.Schema(), nil
+}
+
+// generator generates the struct definitions.
+type generator struct {
+	// nullable wraps the types of nullable columns with [anyarrow.Nullable] instead of pointers.
+	nullable bool
+
+	usesTime     bool
+	usesAnyarrow bool
+	// structs are the generated struct definitions, the top level one first.
+	structs []string
+}
+
+// initialisms are the words written in upper case in go identifiers.
+var initialisms = map[string]bool{
+	"id": true, "ids": true, "url": true, "uri": true, "http": true, "json": true, "xml": true,
+	"api": true, "uid": true, "uuid": true, "ip": true, "sql": true, "utc": true,
+}
+
+// goName converts column name to an exported go identifier, for example trade_id to TradeID.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		b.WriteRune(unicode.ToUpper(r[0]))
+		b.WriteString(string(r[1:]))
+	}
+
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "F" + s
+	}
+
+	return s
+}
+
+// field is the go type and the tag options of a column.
+type field struct {
+	typ     string
+	options []string
+	// nullable indicates the go type can represent nulls without wrapping.
+	nullable bool
+}
+
+// goType maps arrow type dt to go, generating the struct types for struct columns with name.
+func (g *generator) goType(dt arrow.DataType, name string) (field, error) {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return field{typ: "bool"}, nil
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
+		*arrow.Float32Type, *arrow.Float64Type:
+		return field{typ: dt.Name()}, nil
+	case *arrow.StringType, *arrow.LargeStringType:
+		return field{typ: "string"}, nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return field{typ: "[]byte", nullable: true}, nil
+	case *arrow.TimestampType:
+		g.usesTime = true
+		f := field{typ: "time.Time", options: []string{"unit=" + dt.Unit.String()}}
+		if dt.TimeZone != "" && dt.TimeZone != "UTC" {
+			f.options = append(f.options, "tz="+dt.TimeZone)
+		}
+		return f, nil
+	case *arrow.Date32Type, *arrow.Date64Type:
+		g.usesTime = true
+		return field{typ: "time.Time", options: []string{dt.Name()}}, nil
+	case *arrow.DurationType:
+		g.usesTime = true
+		return field{typ: "time.Duration", options: []string{"unit=" + dt.Unit.String()}}, nil
+	case *arrow.Time32Type:
+		return field{typ: "int32"}, nil
+	case *arrow.Time64Type:
+		return field{typ: "int64"}, nil
+	case arrow.DecimalType:
+		f := field{typ: "float64"}
+		if p := dt.GetPrecision(); p <= 38 && dt.GetScale() >= 0 {
+			f.options = []string{"precision=" + strconv.Itoa(int(p)), "scale=" + strconv.Itoa(int(dt.GetScale()))}
+		}
+		return f, nil
+	case *arrow.DictionaryType:
+		f, err := g.goType(dt.ValueType, name)
+		if err == nil && (f.typ == "string" || f.typ == "[]byte") {
+			f.options = append(f.options, "dict")
+		}
+		return f, err
+	case arrow.ListLikeType:
+		elem, err := g.goType(dt.Elem(), name+"Item")
+		if err != nil {
+			return field{}, err
+		}
+		if dt.ElemField().Nullable {
+			elem.typ = g.wrap(elem)
+		}
+		// the options of slices apply to their elements.
+		return field{typ: "[]" + elem.typ, options: elem.options, nullable: true}, nil
+	case *arrow.StructType:
+		if err := g.generateStruct(name, dt.Fields()); err != nil {
+			return field{}, err
+		}
+		return field{typ: name}, nil
+	default:
+		return field{}, errors.ErrUnsupported
+	}
+}
+
+// wrap is the go type of nullable f.
+func (g *generator) wrap(f field) string {
+	switch {
+	case f.nullable:
+		return f.typ
+	case g.nullable:
+		g.usesAnyarrow = true
+		return "anyarrow.Nullable[" + f.typ + "]"
+	default:
+		return "*" + f.typ
+	}
+}
+
+// generateStruct generates the struct type name with fields.
+func (g *generator) generateStruct(name string, fields []arrow.Field) error {
+	idx := len(g.structs)
+	g.structs = append(g.structs, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	used := map[string]bool{}
+	for _, f := range fields {
+		fieldName := goName(f.Name)
+		for i := 2; used[fieldName]; i++ {
+			fieldName = goName(f.Name) + strconv.Itoa(i)
+		}
+
+		gf, err := g.goType(f.Type, name+fieldName)
+		if errors.Is(err, errors.ErrUnsupported) {
+			fmt.Fprintf(&b, "// column %q of type %s is not supported.\n", f.Name, f.Type)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		used[fieldName] = true
+
+		typ := gf.typ
+		if f.Nullable {
+			typ = g.wrap(gf)
+		}
+		tagValue := strings.Join(append([]string{f.Name}, gf.options...), ",")
+		fmt.Fprintf(&b, "%s %s `%s:%s`\n", fieldName, typ, tag.Key, strconv.Quote(tagValue))
+	}
+	b.WriteString("}\n")
+	g.structs[idx] = b.String()
+
+	return nil
+}
+
+// generate generates the go file of package pkg, which defines struct type name for schema.
+func generate(schema *arrow.Schema, name, pkg string, nullable bool) ([]byte, error) {
+	g := &generator{nullable: nullable}
+	if err := g.generateStruct(name, schema.Fields()); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if g.usesTime || g.usesAnyarrow {
+		b.WriteString("import (\n")
+		if g.usesTime {
+			b.WriteString("\"time\"\n\n")
+		}
+		if g.usesAnyarrow {
+			b.WriteString("\"github.com/fardream/anyarrow\"\n")
+		}
+		b.WriteString(")\n\n")
+	}
+	for i, s := range g.structs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(s)
+	}
+
+	return formatSource(b.Bytes())
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	return format.Source(src, format.Options{LangVersion: "v1.23"})
+}
+
+func main() {
+	typeName := flag.String("type", "Row", "name of the generated struct type")
+	pkg := flag.String("package", "main", "package of the generated file")
+	nullable := flag.String("nullable", "pointer", "go type of nullable columns, pointer or nullable for anyarrow.Nullable")
+	output := flag.String("output", "", "output file, default to stdout")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: structgen [flags] file\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*nullable != "pointer" && *nullable != "nullable") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	schema, err := readSchema(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := generate(schema, *typeName, *pkg, *nullable == "nullable")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*output, src, 0o660); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
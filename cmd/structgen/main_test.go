@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/ipc"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+var testSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "trade_id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "symbol", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}},
+	{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "America/New_York"}},
+	{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+	{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+	{Name: "lot", Type: &arrow.Decimal128Type{Precision: 10, Scale: -2}},
+	{Name: "size", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	{Name: "latency", Type: arrow.FixedWidthTypes.Duration_us},
+	{Name: "raw", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "quote", Type: arrow.StructOf(
+		arrow.Field{Name: "bid", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "ask", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	), Nullable: true},
+	{Name: "fills", Type: arrow.ListOf(arrow.PrimitiveTypes.Float32)},
+	{Name: "tags", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)},
+	{Name: "empty", Type: arrow.Null},
+	{Name: "half", Type: arrow.FixedWidthTypes.Float16},
+	{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}},
+	{Name: "1st", Type: arrow.FixedWidthTypes.Boolean},
+}, nil)
+
+const expectedPointer = `package trade
+
+import (
+	"time"
+)
+
+type Trade struct {
+	TradeID int64         ` + "`" + `arrow:"trade_id"` + "`" + `
+	Symbol  string        ` + "`" + `arrow:"symbol,dict"` + "`" + `
+	Time    time.Time     ` + "`" + `arrow:"time,unit=ms,tz=America/New_York"` + "`" + `
+	Date    time.Time     ` + "`" + `arrow:"date,date32"` + "`" + `
+	Price   float64       ` + "`" + `arrow:"price,precision=10,scale=2"` + "`" + `
+	Lot     float64       ` + "`" + `arrow:"lot"` + "`" + `
+	Size    *int32        ` + "`" + `arrow:"size"` + "`" + `
+	Latency time.Duration ` + "`" + `arrow:"latency,unit=us"` + "`" + `
+	Raw     []byte        ` + "`" + `arrow:"raw"` + "`" + `
+	Quote   *TradeQuote   ` + "`" + `arrow:"quote"` + "`" + `
+	Fills   []*float32    ` + "`" + `arrow:"fills"` + "`" + `
+	Tags    []TradeTagsItem ` + "`" + `arrow:"tags"` + "`" + `
+	// column "empty" of type null is not supported.
+	// column "half" of type float16 is not supported.
+	// column "hash" of type fixed_size_binary[2] is not supported.
+	F1st bool ` + "`" + `arrow:"1st"` + "`" + `
+}
+
+type TradeQuote struct {
+	Bid float64  ` + "`" + `arrow:"bid"` + "`" + `
+	Ask *float64 ` + "`" + `arrow:"ask"` + "`" + `
+}
+
+type TradeTagsItem struct {
+	Key   string  ` + "`" + `arrow:"key"` + "`" + `
+	Value *string ` + "`" + `arrow:"value"` + "`" + `
+}
+`
+
+// Trade, TradeQuote and TradeTagsItem are the types in expectedPointer.
+type Trade struct {
+	TradeID int64           `arrow:"trade_id"`
+	Symbol  string          `arrow:"symbol,dict"`
+	Time    time.Time       `arrow:"time,unit=ms,tz=America/New_York"`
+	Date    time.Time       `arrow:"date,date32"`
+	Price   float64         `arrow:"price,precision=10,scale=2"`
+	Lot     float64         `arrow:"lot"`
+	Size    *int32          `arrow:"size"`
+	Latency time.Duration   `arrow:"latency,unit=us"`
+	Raw     []byte          `arrow:"raw"`
+	Quote   *TradeQuote     `arrow:"quote"`
+	Fills   []*float32      `arrow:"fills"`
+	Tags    []TradeTagsItem `arrow:"tags"`
+	F1st    bool            `arrow:"1st"`
+}
+
+type TradeQuote struct {
+	Bid float64  `arrow:"bid"`
+	Ask *float64 `arrow:"ask"`
+}
+
+type TradeTagsItem struct {
+	Key   string  `arrow:"key"`
+	Value *string `arrow:"value"`
+}
+
+func TestUnmarshalGenerated(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewRecordBuilder(mem, testSchema)
+	defer b.Release()
+	for _, f := range b.Fields() {
+		if d, ok := f.(*array.BinaryDictionaryBuilder); ok {
+			// an empty value of a dictionary is an index into an empty dictionary.
+			if err := d.AppendString("X"); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		f.AppendEmptyValue()
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var rows []Trade
+	if err := anyarrow.Unmarshal(rec, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+
+	encoded, err := anyarrow.Marshal(mem, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded.Release()
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(testSchema, "Trade", "trade", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := formatSource([]byte(expectedPointer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, expected) {
+		t.Errorf("expected\n%s\ngot\n%s", expected, src)
+	}
+
+	src, err = generate(testSchema, "Trade", "trade", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"github.com/fardream/anyarrow"`, "anyarrow.Nullable[int32]", "anyarrow.Nullable[TradeQuote]", "[]anyarrow.Nullable[float32]"} {
+		if !bytes.Contains(src, []byte(s)) {
+			t.Errorf("expected %s in\n%s", s, src)
+		}
+	}
+}
+
+func TestReadSchema(t *testing.T) {
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "file.arrow")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	var stream bytes.Buffer
+	sw := ipc.NewWriter(&stream, ipc.WithSchema(testSchema))
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	streamFile := filepath.Join(dir, "stream.arrows")
+	if err := os.WriteFile(streamFile, stream.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{file, streamFile} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema, err := readSchema(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !schema.Equal(testSchema) {
+			t.Errorf("%s: expected schema %s, got %s", name, testSchema, schema)
+		}
+	}
+}
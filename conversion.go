@@ -0,0 +1,224 @@
+package anyarrow
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+// ConversionKind classifies how the values of an arrow type are converted to a go type by the accessors.
+type ConversionKind uint8
+
+const (
+	// ConversionUnsupported indicates the arrow type cannot be read as the go type.
+	ConversionUnsupported ConversionKind = iota
+	// ConversionDirect indicates the values are read as they are stored, for example int64 from timestamps,
+	// or strings from binaries.
+	ConversionDirect
+	// ConversionWidening indicates every value is converted without loss, for example int16 to int64,
+	// uint32 to float64, booleans to numbers, or nulls, which have no values, to anything.
+	ConversionWidening
+	// ConversionNarrowing indicates values may wrap around, be truncated or lose precision,
+	// for example int64 to int32, floats to integers, or decimals to floats.
+	ConversionNarrowing
+)
+
+func (k ConversionKind) String() string {
+	switch k {
+	case ConversionDirect:
+		return "direct"
+	case ConversionWidening:
+		return "lossless widening"
+	case ConversionNarrowing:
+		return "lossy narrowing"
+	default:
+		return "unsupported"
+	}
+}
+
+// Conversion describes how the accessors convert the values of an arrow type to a go type, see [CanConvert].
+type Conversion struct {
+	DataType arrow.DataType
+	GoType   reflect.Type
+	// Kind is the conversion of the values, which are the dictionary values for dictionaries.
+	Kind ConversionKind
+	// Dictionary indicates the values are looked up by the indices of a dictionary.
+	Dictionary bool
+}
+
+func (c Conversion) String() string {
+	s := fmt.Sprintf("%s to gotype %s: %s", c.DataType, c.GoType, c.Kind)
+	if c.Dictionary && c.Kind != ConversionUnsupported {
+		s += " of dictionary values"
+	}
+
+	return s
+}
+
+// CanConvert reports how the NewXxx function accepting target, or NewXxxOf when target is a named type,
+// converts arrays of type dt.
+//
+// If the arrays cannot be read as target, the returned error is the one the NewXxx function returns,
+// which is an [*UnsupportedTypeError] or an [*UnsupportedDictionaryError].
+func CanConvert(dt arrow.DataType, target reflect.Type) (Conversion, error) {
+	c := Conversion{DataType: dt, GoType: target}
+
+	d, ok := dt.(*arrow.DictionaryType)
+	if !ok {
+		c.Kind = conversionKind(dt, target.Kind())
+		if c.Kind == ConversionUnsupported {
+			return c, &UnsupportedTypeError{DataType: dt, GoType: target}
+		}
+		return c, nil
+	}
+
+	c.Dictionary = true
+	if isIndexType(d.IndexType) {
+		c.Kind = conversionKind(d.ValueType, target.Kind())
+	}
+	if c.Kind == ConversionUnsupported {
+		return c, &UnsupportedDictionaryError{DataType: d, GoType: target}
+	}
+
+	return c, nil
+}
+
+// isIndexType checks if dt can be the index type of dictionaries read by the accessors, see indexBuffer.
+func isIndexType(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64, arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return true
+	default:
+		return false
+	}
+}
+
+// conversionKind classifies the conversion of dt, which is not a dictionary, to go types of kind k.
+func conversionKind(dt arrow.DataType, k reflect.Kind) ConversionKind {
+	if k == reflect.String {
+		switch dt.ID() {
+		case arrow.STRING, arrow.BINARY, arrow.LARGE_STRING, arrow.LARGE_BINARY:
+			return ConversionDirect
+		case arrow.NULL:
+			return ConversionWidening
+		default:
+			return ConversionUnsupported
+		}
+	}
+	if kindBits(k) == 0 {
+		return ConversionUnsupported
+	}
+	if dt.ID() == arrow.NULL {
+		return ConversionWidening
+	}
+
+	if d, ok := dt.(arrow.DecimalType); ok {
+		return decimalConversionKind(d, k)
+	}
+
+	src := physicalKind(dt)
+	switch {
+	case src == reflect.Invalid:
+		return ConversionUnsupported
+	case src == k:
+		return ConversionDirect
+	case src == reflect.Bool || isWidening(src, k):
+		return ConversionWidening
+	default:
+		return ConversionNarrowing
+	}
+}
+
+// physicalKind is the kind of the values of dt as stored by arrow, or reflect.Invalid if dt is not a number or boolean.
+func physicalKind(dt arrow.DataType) reflect.Kind {
+	switch dt.ID() {
+	case arrow.BOOL:
+		return reflect.Bool
+	case arrow.INT8:
+		return reflect.Int8
+	case arrow.INT16:
+		return reflect.Int16
+	case arrow.INT32, arrow.DATE32, arrow.TIME32:
+		return reflect.Int32
+	case arrow.INT64, arrow.DATE64, arrow.TIME64, arrow.TIMESTAMP, arrow.DURATION:
+		return reflect.Int64
+	case arrow.UINT8:
+		return reflect.Uint8
+	case arrow.UINT16:
+		return reflect.Uint16
+	case arrow.UINT32:
+		return reflect.Uint32
+	case arrow.UINT64:
+		return reflect.Uint64
+	case arrow.FLOAT32:
+		return reflect.Float32
+	case arrow.FLOAT64:
+		return reflect.Float64
+	default:
+		return reflect.Invalid
+	}
+}
+
+// kindBits is the size in bits of the numbers of kind k read by the accessors, or 0 if k is not one of them.
+func kindBits(k reflect.Kind) int {
+	switch k {
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 32
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// mantissaBits is the number of bits of integers that floats of kind k represent exactly.
+func mantissaBits(k reflect.Kind) int {
+	if k == reflect.Float32 {
+		return 24
+	}
+
+	return 53
+}
+
+// isWidening checks if every number of kind src converts to kind dst without loss.
+func isWidening(src, dst reflect.Kind) bool {
+	switch {
+	case isFloatKind(dst) && isFloatKind(src):
+		return kindBits(dst) > kindBits(src)
+	case isFloatKind(dst):
+		return kindBits(src) < mantissaBits(dst)
+	case isFloatKind(src):
+		return false
+	case isUnsignedKind(dst) != isUnsignedKind(src):
+		// only unsigned integers fit into larger signed integers.
+		return isUnsignedKind(src) && kindBits(dst) > kindBits(src)
+	default:
+		return kindBits(dst) > kindBits(src)
+	}
+}
+
+// decimalDigits is the number of decimal digits of integers that numbers of kind k represent exactly.
+var decimalDigits = map[reflect.Kind]int32{
+	reflect.Int8:    2,
+	reflect.Int16:   4,
+	reflect.Int32:   9,
+	reflect.Int64:   18,
+	reflect.Float32: 7,
+	reflect.Float64: 15,
+}
+
+// decimalConversionKind classifies the conversion of decimals of dt to kind k,
+// which is only lossless for integer decimals with few enough digits.
+func decimalConversionKind(dt arrow.DecimalType, k reflect.Kind) ConversionKind {
+	digits, ok := decimalDigits[k]
+	if !ok || dt.GetScale() > 0 || dt.GetPrecision()-dt.GetScale() > digits {
+		return ConversionNarrowing
+	}
+
+	return ConversionWidening
+}
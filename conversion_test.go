@@ -0,0 +1,190 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// accessorConstructors are the constructors of the accessors, by the go type they read.
+var accessorConstructors = map[reflect.Type]func(arrow.Array) error{
+	reflect.TypeFor[int8]():    func(a arrow.Array) error { return release(anyarrow.NewInt8(a)) },
+	reflect.TypeFor[int16]():   func(a arrow.Array) error { return release(anyarrow.NewInt16(a)) },
+	reflect.TypeFor[int32]():   func(a arrow.Array) error { return release(anyarrow.NewInt32(a)) },
+	reflect.TypeFor[int64]():   func(a arrow.Array) error { return release(anyarrow.NewInt64(a)) },
+	reflect.TypeFor[uint8]():   func(a arrow.Array) error { return release(anyarrow.NewUint8(a)) },
+	reflect.TypeFor[uint16]():  func(a arrow.Array) error { return release(anyarrow.NewUint16(a)) },
+	reflect.TypeFor[uint32]():  func(a arrow.Array) error { return release(anyarrow.NewUint32(a)) },
+	reflect.TypeFor[uint64]():  func(a arrow.Array) error { return release(anyarrow.NewUint64(a)) },
+	reflect.TypeFor[float32](): func(a arrow.Array) error { return release(anyarrow.NewFloat32(a)) },
+	reflect.TypeFor[float64](): func(a arrow.Array) error { return release(anyarrow.NewFloat64(a)) },
+	reflect.TypeFor[string]():  func(a arrow.Array) error { return release(anyarrow.NewString(a)) },
+}
+
+func release[A interface{ Release() }](a A, err error) error {
+	if err == nil {
+		a.Release()
+	}
+
+	return err
+}
+
+func TestCanConvertAgreesWithConstructors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	types := []arrow.DataType{
+		arrow.PrimitiveTypes.Int8, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Uint8, arrow.PrimitiveTypes.Uint16, arrow.PrimitiveTypes.Uint32, arrow.PrimitiveTypes.Uint64,
+		arrow.PrimitiveTypes.Float32, arrow.PrimitiveTypes.Float64, arrow.FixedWidthTypes.Float16,
+		arrow.PrimitiveTypes.Date32, arrow.PrimitiveTypes.Date64,
+		arrow.FixedWidthTypes.Time32ms, arrow.FixedWidthTypes.Time64us,
+		arrow.FixedWidthTypes.Timestamp_ms, arrow.FixedWidthTypes.Duration_ns, arrow.FixedWidthTypes.Boolean,
+		&arrow.Decimal128Type{Precision: 10, Scale: 2}, &arrow.Decimal256Type{Precision: 40, Scale: 0},
+		arrow.BinaryTypes.String, arrow.BinaryTypes.Binary, arrow.BinaryTypes.LargeString, arrow.BinaryTypes.LargeBinary,
+		arrow.FixedWidthTypes.MonthInterval, arrow.ListOf(arrow.PrimitiveTypes.Int64),
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.PrimitiveTypes.Float32},
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint8, ValueType: arrow.BinaryTypes.String},
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.FixedWidthTypes.Float16},
+	}
+
+	for _, dt := range types {
+		b := array.NewBuilder(mem, dt)
+		arr := b.NewArray()
+		b.Release()
+		for target, newAccessor := range accessorConstructors {
+			_, canErr := anyarrow.CanConvert(dt, target)
+			newErr := newAccessor(arr)
+			if (canErr == nil) != (newErr == nil) {
+				t.Errorf("%s to %s: CanConvert returned %v but constructor returned %v", dt, target, canErr, newErr)
+			}
+			if canErr != nil && newErr != nil && canErr.Error() != newErr.Error() {
+				t.Errorf("%s to %s: expected error %q, got %q", dt, target, newErr, canErr)
+			}
+		}
+		arr.Release()
+	}
+}
+
+func TestCanConvertKinds(t *testing.T) {
+	tests := []struct {
+		dt         arrow.DataType
+		target     reflect.Type
+		kind       anyarrow.ConversionKind
+		dictionary bool
+	}{
+		{arrow.PrimitiveTypes.Int64, reflect.TypeFor[int64](), anyarrow.ConversionDirect, false},
+		{arrow.FixedWidthTypes.Timestamp_ms, reflect.TypeFor[int64](), anyarrow.ConversionDirect, false},
+		{arrow.PrimitiveTypes.Int32, reflect.TypeFor[Price](), anyarrow.ConversionWidening, false},
+		{arrow.PrimitiveTypes.Uint32, reflect.TypeFor[int64](), anyarrow.ConversionWidening, false},
+		{arrow.PrimitiveTypes.Int16, reflect.TypeFor[float32](), anyarrow.ConversionWidening, false},
+		{arrow.FixedWidthTypes.Boolean, reflect.TypeFor[uint8](), anyarrow.ConversionWidening, false},
+		{arrow.PrimitiveTypes.Int64, reflect.TypeFor[float64](), anyarrow.ConversionNarrowing, false},
+		{arrow.PrimitiveTypes.Int8, reflect.TypeFor[uint64](), anyarrow.ConversionNarrowing, false},
+		{arrow.PrimitiveTypes.Float64, reflect.TypeFor[float32](), anyarrow.ConversionNarrowing, false},
+		{arrow.PrimitiveTypes.Float32, reflect.TypeFor[int64](), anyarrow.ConversionNarrowing, false},
+		{&arrow.Decimal128Type{Precision: 9, Scale: 0}, reflect.TypeFor[int32](), anyarrow.ConversionWidening, false},
+		{&arrow.Decimal128Type{Precision: 10, Scale: 2}, reflect.TypeFor[float64](), anyarrow.ConversionNarrowing, false},
+		{arrow.BinaryTypes.Binary, reflect.TypeFor[string](), anyarrow.ConversionDirect, false},
+		{&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int32}, reflect.TypeFor[int64](), anyarrow.ConversionWidening, true},
+		{arrow.BinaryTypes.String, reflect.TypeFor[int64](), anyarrow.ConversionUnsupported, false},
+		{arrow.PrimitiveTypes.Int64, reflect.TypeFor[int](), anyarrow.ConversionUnsupported, false},
+	}
+
+	for _, test := range tests {
+		c, err := anyarrow.CanConvert(test.dt, test.target)
+		if c.Kind != test.kind || c.Dictionary != test.dictionary {
+			t.Errorf("%s to %s: expected %s with dictionary %t, got %s with dictionary %t", test.dt, test.target, test.kind, test.dictionary, c.Kind, c.Dictionary)
+		}
+		if (err == nil) != (test.kind != anyarrow.ConversionUnsupported) {
+			t.Errorf("%s to %s: unexpected error %v", test.dt, test.target, err)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ints := anyarrow.FromSlice([]int32{1, 2}, nil)
+	defer ints.Release()
+	strs := anyarrow.FromStrings(mem, []string{"a"}, nil)
+	defer strs.Release()
+	dict := newDictionary(t, mem, []int16{0, 0}, nil, strs)
+	defer dict.Release()
+
+	a, err := anyarrow.NewInt32(ints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Release()
+	b, err := anyarrow.NewFloat64Of[Price](ints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Release()
+	c, err := anyarrow.NewString(dict, anyarrow.WithDecodedDictionary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Release()
+
+	tests := []struct {
+		explained string
+		expected  string
+	}{
+		{a.Explain(), "int32 to gotype int32: direct, read directly from array.Int32"},
+		{b.Explain(), "int32 to gotype anyarrow_test.Price: lossless widening, converted on every read"},
+		{c.Explain(), "to gotype string: direct of dictionary values, decoded once into a slice"},
+	}
+	for _, test := range tests {
+		if !strings.HasSuffix(test.explained, test.expected) {
+			t.Errorf("expected %q to end with %q", test.explained, test.expected)
+		}
+	}
+}
+
+func TestWithLossless(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	int64s := anyarrow.FromSlice([]int64{1, 2}, nil)
+	defer int64s.Release()
+	int16s := anyarrow.FromSlice([]int16{1, 2}, nil)
+	defer int16s.Release()
+	float64s := anyarrow.FromSlice([]float64{1.5}, nil)
+	defer float64s.Release()
+
+	var lossyErr *anyarrow.LossyConversionError
+	if _, err := anyarrow.NewInt32(int64s, anyarrow.WithLossless()); !errors.As(err, &lossyErr) {
+		t.Errorf("expected LossyConversionError for int64 to int32, got %v", err)
+	}
+	if _, err := anyarrow.NewFloat32(float64s, anyarrow.WithLossless()); !errors.As(err, &lossyErr) {
+		t.Errorf("expected LossyConversionError for float64 to float32, got %v", err)
+	}
+
+	a, err := anyarrow.NewInt32(int16s, anyarrow.WithLossless())
+	if err != nil {
+		t.Fatalf("expected int16 to be accepted, got %v", err)
+	}
+	defer a.Release()
+	if err := a.Reset(int64s); !errors.As(err, &lossyErr) {
+		t.Errorf("expected LossyConversionError from Reset, got %v", err)
+	}
+	if a.Value(1) != 2 {
+		t.Errorf("expected the accessor to be unchanged, got %d", a.Value(1))
+	}
+
+	b, err := anyarrow.NewInt32(int64s)
+	if err != nil {
+		t.Fatalf("expected int64 to be accepted without WithLossless, got %v", err)
+	}
+	b.Release()
+}
@@ -0,0 +1,125 @@
+package anyarrow
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/decimal256"
+)
+
+// fromBool converts true to 1 and false to 0.
+func fromBool[T number](b bool) T {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// decimal128Int returns the integer part of n with the given scale, truncated toward zero.
+// Integer parts that don't fit into int64 wrap around, like a go conversion between integers.
+func decimal128Int(n decimal128.Num, scale int32) int64 {
+	switch {
+	case scale > 0:
+		n = n.ReduceScaleBy(scale, false)
+	case scale < 0:
+		n = n.IncreaseScaleBy(-scale)
+	}
+
+	return int64(n.LowBits())
+}
+
+// decimal256Int returns the integer part of n with the given scale, truncated toward zero.
+// Integer parts that don't fit into int64 wrap around, like a go conversion between integers.
+func decimal256Int(n decimal256.Num, scale int32) int64 {
+	switch {
+	case scale > 0:
+		n = n.ReduceScaleBy(scale, false)
+	case scale < 0:
+		n = n.IncreaseScaleBy(-scale)
+	}
+
+	return int64(n.LowBits())
+}
+
+// isFloat checks if T is a floating point number.
+func isFloat[T number]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// intFits checks if x converts to T exactly.
+func intFits[T number](x int64) bool {
+	t := T(x)
+	if isFloat[T]() {
+		f := float64(t)
+		return f >= math.MinInt64 && f < math.MaxInt64 && int64(f) == x
+	}
+
+	return int64(t) == x && (t < 0) == (x < 0)
+}
+
+// uintFits checks if x converts to T exactly.
+func uintFits[T number](x uint64) bool {
+	t := T(x)
+	if isFloat[T]() {
+		f := float64(t)
+		return f < math.MaxUint64 && uint64(f) == x
+	}
+
+	return uint64(t) == x && t >= 0
+}
+
+// floatFits checks if x converts to T exactly, where NaN fits floats and only integers in the range of T fit integers.
+func floatFits[T number](x float64) bool {
+	if isFloat[T]() {
+		return float64(T(x)) == x || math.IsNaN(x)
+	}
+	if math.Trunc(x) != x {
+		return false
+	}
+
+	bits := 8 * int(unsafe.Sizeof(T(0)))
+	minusOne := -1.0
+	if T(minusOne) < 0 {
+		return x >= -math.Ldexp(1, bits-1) && x < math.Ldexp(1, bits-1)
+	}
+
+	return x >= 0 && x < math.Ldexp(1, bits)
+}
+
+// decimal128Fits checks if n with the given scale converts to T exactly,
+// which for floats means the decimal is recovered from the float.
+func decimal128Fits[T number](n decimal128.Num, scale int32) bool {
+	if isFloat[T]() {
+		back, err := decimal128.FromFloat64(float64(T(n.ToFloat64(scale))), 38, scale)
+		return err == nil && back == n
+	}
+
+	x := decimal128.FromI64(decimal128Int(n, scale))
+	if scale > 0 {
+		x = x.IncreaseScaleBy(scale)
+	} else if scale < 0 {
+		x = x.ReduceScaleBy(-scale, false)
+	}
+
+	return x == n && intFits[T](decimal128Int(n, scale))
+}
+
+// decimal256Fits checks if n with the given scale converts to T exactly, see decimal128Fits.
+func decimal256Fits[T number](n decimal256.Num, scale int32) bool {
+	if isFloat[T]() {
+		back, err := decimal256.FromFloat64(float64(T(n.ToFloat64(scale))), 76, scale)
+		return err == nil && back == n
+	}
+
+	x := decimal256.FromI64(decimal256Int(n, scale))
+	if scale > 0 {
+		x = x.IncreaseScaleBy(scale)
+	} else if scale < 0 {
+		x = x.ReduceScaleBy(-scale, false)
+	}
+
+	return x == n && intFits[T](decimal256Int(n, scale))
+}
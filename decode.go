@@ -0,0 +1,411 @@
+package anyarrow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+)
+
+// Unmarshal decodes the rows of rec into dst, which is resized to the number of rows of rec.
+//
+// Columns are mapped to the fields of T by name, see [NewDecoder] for the rules.
+// The decoded values don't share memory with rec, which can be released right after.
+func Unmarshal[T any](rec arrow.Record, dst *[]T) error {
+	d, err := NewDecoder[T](rec)
+	if err != nil {
+		return err
+	}
+	defer d.Release()
+
+	n := int(rec.NumRows())
+	*dst = slices.Grow((*dst)[:0], n)[:n]
+	var zero T
+	for i := range *dst {
+		(*dst)[i] = zero
+		d.DecodeRow(i, &(*dst)[i])
+	}
+
+	return nil
+}
+
+// Decoder decodes the rows of an [arrow.Record] into go structs of type T.
+type Decoder[T any] struct {
+	rec    arrow.Record
+	fields []fieldDecoder
+}
+
+// NewDecoder creates a [Decoder] for the rows of rec.
+//
+// The exported fields of T are mapped to the columns of rec by the name in the `arrow:"name"` tag,
+// or by the field name if the field is not tagged. Fields tagged `arrow:"-"` are skipped,
+// and the fields of embedded structs are treated as fields of T.
+//
+// Fields are decoded by the accessors of this package according to their kinds,
+// for example an int32 field is read by [Int64] and a float32 field by [Float64].
+// Additionally,
+//   - time.Time fields are read from timestamp and date columns.
+//   - time.Duration fields are read from duration columns with the unit of the column.
+//   - bool fields are true when the value is not zero, which includes boolean columns.
+//   - []byte fields are read from string and binary columns.
+//   - struct fields are read from struct columns, mapping the fields of the struct columns by name.
+//   - slice fields are read from list columns.
+//
+// Null elements are decoded as nil for pointer fields, as invalid [Nullable] for [Nullable] fields,
+// and as zero value for other fields.
+// Strings and []byte are copied, so the decoded values remain valid after the record is released.
+//
+// All the fields of T are checked when the decoder is created,
+// and the returned error lists every column that is missing or cannot be decoded into its field.
+func NewDecoder[T any](rec arrow.Record) (*Decoder[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot decode records into %s, which is not a struct", t)
+	}
+
+	names := make([]string, rec.NumCols())
+	for i, f := range rec.Schema().Fields() {
+		names[i] = f.Name
+	}
+	fields, err := newFieldDecoders(t, names, rec.Columns())
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Retain()
+
+	return &Decoder[T]{rec: rec, fields: fields}, nil
+}
+
+// DecodeRow decodes row i into dst.
+func (d *Decoder[T]) DecodeRow(i int, dst *T) {
+	v := reflect.ValueOf(dst).Elem()
+	for _, f := range d.fields {
+		decodeInto(f.dec, i, fieldByIndex(v, f.index))
+	}
+}
+
+// Release releases the record and the accessors held by the decoder.
+func (d *Decoder[T]) Release() {
+	releaseFieldDecoders(d.fields)
+	d.rec.Release()
+}
+
+// decoder decodes the elements of an arrow array into go values.
+type decoder interface {
+	// isNull checks if the element at index i should be decoded as null.
+	isNull(i int) bool
+	// decode sets v to the element at index i, which is not null.
+	decode(i int, v reflect.Value)
+	release()
+}
+
+// decodeInto sets v to the element at index i, or to zero value if the element is null.
+func decodeInto(d decoder, i int, v reflect.Value) {
+	if d.isNull(i) {
+		v.SetZero()
+		return
+	}
+
+	d.decode(i, v)
+}
+
+type fieldDecoder struct {
+	index []int
+	dec   decoder
+}
+
+// newFieldDecoders creates the decoders for the fields of struct type t from the columns with names.
+func newFieldDecoders(t reflect.Type, names []string, columns []arrow.Array) ([]fieldDecoder, error) {
+	var fields []fieldDecoder
+	var errs []error
+	for _, sf := range structFields(t) {
+		idx := slices.Index(names, sf.name)
+		if idx < 0 {
+			errs = append(errs, fmt.Errorf("missing column %q for field %s.%s", sf.name, t, fieldName(t, sf.index)))
+			continue
+		}
+		dec, err := newDecoder(columns[idx], sf.typ)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot decode column %q into field %s.%s: %w", sf.name, t, fieldName(t, sf.index), err))
+			continue
+		}
+		fields = append(fields, fieldDecoder{index: sf.index, dec: dec})
+	}
+
+	if len(errs) > 0 {
+		releaseFieldDecoders(fields)
+		return nil, errors.Join(errs...)
+	}
+
+	return fields, nil
+}
+
+func releaseFieldDecoders(fields []fieldDecoder) {
+	for _, f := range fields {
+		f.dec.release()
+	}
+}
+
+// fieldName is the dot separated name of the field of struct type t by index.
+func fieldName(t reflect.Type, index []int) string {
+	var name string
+	for i, x := range index {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		f := t.Field(x)
+		if i > 0 {
+			name += "."
+		}
+		name += f.Name
+		t = f.Type
+	}
+
+	return name
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+	bytesType    = reflect.TypeFor[[]byte]()
+)
+
+// isNullable checks if t is an instance of [Nullable].
+func isNullable(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t.PkgPath() == reflect.TypeFor[Nullable[int]]().PkgPath() &&
+		strings.HasPrefix(t.Name(), "Nullable[")
+}
+
+// valueType returns the data type of the values of arr, which is the value type for dictionaries.
+func valueType(arr arrow.Array) arrow.DataType {
+	if dt, ok := arr.DataType().(*arrow.DictionaryType); ok {
+		return dt.ValueType
+	}
+
+	return arr.DataType()
+}
+
+// newDecoder creates the decoder for arr into go values of type t.
+func newDecoder(arr arrow.Array, t reflect.Type) (decoder, error) {
+	switch {
+	case t.Kind() == reflect.Pointer:
+		elem, err := newDecoder(arr, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &pointerDecoder{elem: elem}, nil
+	case isNullable(t):
+		elem, err := newDecoder(arr, t.Field(0).Type)
+		if err != nil {
+			return nil, err
+		}
+		return &nullableDecoder{elem: elem}, nil
+	case t == timeType:
+		return newTimeDecoder(arr)
+	case t == durationType && valueType(arr).ID() == arrow.DURATION:
+		toDuration, err := DurationFunc(arr.DataType())
+		if err != nil {
+			return nil, err
+		}
+		return newValueDecoder(NewInt64, arr, func(v reflect.Value, x int64) {
+			v.SetInt(int64(toDuration(x)))
+		})
+	case t == bytesType || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && !isListLike(arr)):
+		return newValueDecoder(NewString, arr, func(v reflect.Value, x string) {
+			v.SetBytes(append(v.Bytes()[:0], x...))
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return newValueDecoder(NewFloat64, arr, func(v reflect.Value, x float64) {
+			v.SetBool(x != 0)
+		})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return newValueDecoder(NewInt64, arr, reflect.Value.SetInt)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return newValueDecoder(NewUint64, arr, reflect.Value.SetUint)
+	case reflect.Float32, reflect.Float64:
+		return newValueDecoder(NewFloat64, arr, reflect.Value.SetFloat)
+	case reflect.String:
+		return newValueDecoder(NewString, arr, func(v reflect.Value, x string) {
+			// x shares the memory of the record, which may be released and reused.
+			v.SetString(strings.Clone(x))
+		})
+	case reflect.Slice:
+		list, ok := arr.(array.ListLike)
+		if !ok {
+			return nil, &UnsupportedTypeError{DataType: arr.DataType(), GoType: t}
+		}
+		elem, err := newDecoder(list.ListValues(), t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &listDecoder{list: list, elem: elem}, nil
+	case reflect.Struct:
+		st, ok := arr.(*array.Struct)
+		if !ok {
+			return nil, &UnsupportedTypeError{DataType: arr.DataType(), GoType: t}
+		}
+		dt := st.DataType().(*arrow.StructType)
+		names := make([]string, st.NumField())
+		columns := make([]arrow.Array, st.NumField())
+		for i, f := range dt.Fields() {
+			names[i], columns[i] = f.Name, st.Field(i)
+		}
+		fields, err := newFieldDecoders(t, names, columns)
+		if err != nil {
+			return nil, err
+		}
+		return &structDecoder{arr: st, fields: fields}, nil
+	default:
+		return nil, fmt.Errorf("unsupported go type %s", t)
+	}
+}
+
+func isListLike(arr arrow.Array) bool {
+	_, ok := arr.(array.ListLike)
+	return ok
+}
+
+// accessor is implemented by all the accessors of this package.
+type accessor[T any] interface {
+	IsNull(i int) bool
+	Value(i int) T
+	Release()
+}
+
+// valueDecoder decodes elements read by an accessor.
+type valueDecoder[T any] struct {
+	a   accessor[T]
+	set func(v reflect.Value, x T)
+}
+
+func newValueDecoder[A accessor[T], T any](newAccessor func(arrow.Array, ...Option) (A, error), arr arrow.Array, set func(reflect.Value, T)) (decoder, error) {
+	a, err := newAccessor(arr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valueDecoder[T]{a: a, set: set}, nil
+}
+
+func (d *valueDecoder[T]) isNull(i int) bool {
+	return d.a.IsNull(i)
+}
+
+func (d *valueDecoder[T]) decode(i int, v reflect.Value) {
+	d.set(v, d.a.Value(i))
+}
+
+func (d *valueDecoder[T]) release() {
+	d.a.Release()
+}
+
+// newTimeDecoder creates the decoder for arr into time.Time.
+func newTimeDecoder(arr arrow.Array) (decoder, error) {
+	toTime, err := TimeFunc(arr.DataType())
+	if err != nil {
+		return nil, err
+	}
+
+	return newValueDecoder(NewInt64, arr, func(v reflect.Value, x int64) {
+		*v.Addr().Interface().(*time.Time) = toTime(x)
+	})
+}
+
+// pointerDecoder decodes into pointers, which are nil for null elements.
+type pointerDecoder struct {
+	elem decoder
+}
+
+func (d *pointerDecoder) isNull(i int) bool {
+	return d.elem.isNull(i)
+}
+
+func (d *pointerDecoder) decode(i int, v reflect.Value) {
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	d.elem.decode(i, v.Elem())
+}
+
+func (d *pointerDecoder) release() {
+	d.elem.release()
+}
+
+// nullableDecoder decodes into [Nullable].
+type nullableDecoder struct {
+	elem decoder
+}
+
+func (d *nullableDecoder) isNull(int) bool {
+	return false
+}
+
+func (d *nullableDecoder) decode(i int, v reflect.Value) {
+	if d.elem.isNull(i) {
+		v.SetZero()
+		return
+	}
+	d.elem.decode(i, v.Field(0))
+	v.Field(1).SetBool(true)
+}
+
+func (d *nullableDecoder) release() {
+	d.elem.release()
+}
+
+// listDecoder decodes list columns into slices.
+type listDecoder struct {
+	list array.ListLike
+	elem decoder
+}
+
+func (d *listDecoder) isNull(i int) bool {
+	return d.list.IsNull(i)
+}
+
+func (d *listDecoder) decode(i int, v reflect.Value) {
+	start, end := d.list.ValueOffsets(i)
+	n := int(end - start)
+	if !v.IsNil() && v.Cap() >= n {
+		v.SetLen(n)
+	} else {
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+	}
+	for j := range n {
+		decodeInto(d.elem, int(start)+j, v.Index(j))
+	}
+}
+
+func (d *listDecoder) release() {
+	d.elem.release()
+}
+
+// structDecoder decodes struct columns into structs.
+type structDecoder struct {
+	arr    *array.Struct
+	fields []fieldDecoder
+}
+
+func (d *structDecoder) isNull(i int) bool {
+	return d.arr.IsNull(i)
+}
+
+func (d *structDecoder) decode(i int, v reflect.Value) {
+	for _, f := range d.fields {
+		decodeInto(f.dec, i, fieldByIndex(v, f.index))
+	}
+}
+
+func (d *structDecoder) release() {
+	releaseFieldDecoders(d.fields)
+}
@@ -0,0 +1,175 @@
+package anyarrow_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+type decodeMeta struct {
+	Source string `arrow:"source"`
+}
+
+type decodeQuote struct {
+	Bid float64 `arrow:"bid"`
+	Ask float64 `arrow:"ask"`
+}
+
+type decodeRow struct {
+	decodeMeta
+	Symbol  string                     `arrow:"symbol"`
+	Time    time.Time                  `arrow:"time"`
+	Price   float32                    `arrow:"price"`
+	Size    *int32                     `arrow:"size"`
+	Venue   anyarrow.Nullable[string]  `arrow:"venue"`
+	Flag    bool                       `arrow:"flag"`
+	Quote   decodeQuote                `arrow:"quote"`
+	Fills   []int64                    `arrow:"fills"`
+	Latency time.Duration              `arrow:"latency"`
+	Ignored string                     `arrow:"-"`
+	Extra   anyarrow.Nullable[float64] `arrow:"extra"`
+}
+
+func newRecord(t *testing.T, mem memory.Allocator, schema *arrow.Schema, json string) arrow.Record {
+	t.Helper()
+
+	rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec
+}
+
+func TestUnmarshal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "source", Type: arrow.BinaryTypes.String},
+		{Name: "symbol", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}},
+		{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+		{Name: "price", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "size", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "venue", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "flag", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "quote", Type: arrow.StructOf(
+			arrow.Field{Name: "bid", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "ask", Type: arrow.PrimitiveTypes.Float64},
+		)},
+		{Name: "fills", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+		{Name: "latency", Type: arrow.FixedWidthTypes.Duration_ms},
+		{Name: "extra", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+	}, nil)
+
+	rec := newRecord(t, mem, schema, `[
+		{"source": "a", "symbol": "X", "time": 1000, "price": 1.5, "size": 10, "venue": "N", "flag": true,
+		 "quote": {"bid": 1, "ask": 2}, "fills": [1, 2], "latency": 3, "extra": 4},
+		{"source": "b", "symbol": "Y", "time": 2000, "price": 2.5, "size": null, "venue": null, "flag": false,
+		 "quote": null, "fills": [], "latency": 5, "extra": null}
+	]`)
+	defer rec.Release()
+
+	size := int32(10)
+	expected := []decodeRow{
+		{
+			decodeMeta: decodeMeta{Source: "a"},
+			Symbol:     "X",
+			Time:       time.UnixMilli(1000).UTC(),
+			Price:      1.5,
+			Size:       &size,
+			Venue:      anyarrow.Nullable[string]{Value: "N", Valid: true},
+			Flag:       true,
+			Quote:      decodeQuote{Bid: 1, Ask: 2},
+			Fills:      []int64{1, 2},
+			Latency:    3 * time.Millisecond,
+			Extra:      anyarrow.Nullable[float64]{Value: 4, Valid: true},
+		},
+		{
+			decodeMeta: decodeMeta{Source: "b"},
+			Symbol:     "Y",
+			Time:       time.UnixMilli(2000).UTC(),
+			Price:      2.5,
+			Fills:      []int64{},
+			Latency:    5 * time.Millisecond,
+		},
+	}
+
+	rows := []decodeRow{{Ignored: "overwritten"}}
+	if err := anyarrow.Unmarshal(rec, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %+v, got %+v", expected, rows)
+	}
+}
+
+// scribbleAllocator overwrites the memory it frees, like a pool reusing it.
+type scribbleAllocator struct {
+	memory.Allocator
+}
+
+func (a scribbleAllocator) Free(b []byte) {
+	for i := range b {
+		b[i] = 'X'
+	}
+	a.Allocator.Free(b)
+}
+
+func TestUnmarshalAfterRelease(t *testing.T) {
+	type row struct {
+		S string                    `arrow:"s"`
+		P *string                   `arrow:"p"`
+		N anyarrow.Nullable[string] `arrow:"n"`
+		B []byte                    `arrow:"b"`
+	}
+	hello, world := "hello", "world"
+	expected := []row{
+		{S: "hello", P: &hello, N: anyarrow.Nullable[string]{Value: "hello", Valid: true}, B: []byte("hello")},
+		{S: "world", P: &world, N: anyarrow.Nullable[string]{Value: "world", Valid: true}, B: []byte("world")},
+	}
+
+	rec, err := anyarrow.Marshal(scribbleAllocator{memory.NewGoAllocator()}, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []row
+	err = anyarrow.Unmarshal(rec, &rows)
+	rec.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %+v, got %+v", expected, rows)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "symbol", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "price", Type: arrow.PrimitiveTypes.Float64},
+	}, nil)
+	rec := newRecord(t, mem, schema, `[{"symbol": 1, "price": 2}]`)
+	defer rec.Release()
+
+	var rows []decodeRow
+	err := anyarrow.Unmarshal(rec, &rows)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, s := range []string{`missing column "time"`, `missing column "source"`, `cannot decode column "symbol"`} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected error %q to contain %q", err, s)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package anyarrow_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// newDictionary creates a dictionary array with int16 indices into values.
+func newDictionary(t *testing.T, mem memory.Allocator, indices []int16, valid []bool, values arrow.Array) arrow.Array {
+	t.Helper()
+
+	ib := array.NewInt16Builder(mem)
+	defer ib.Release()
+	ib.AppendValues(indices, valid)
+	idx := ib.NewArray()
+	defer idx.Release()
+
+	return array.NewDictionaryArray(&arrow.DictionaryType{IndexType: idx.DataType(), ValueType: values.DataType()}, idx, values)
+}
+
+func TestDictionaryValueTypes(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	tb := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Millisecond})
+	defer tb.Release()
+	tb.AppendValues([]arrow.Timestamp{1000, 2000}, nil)
+	timestamps := tb.NewArray()
+	defer timestamps.Release()
+
+	db := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 10, Scale: 2})
+	defer db.Release()
+	db.AppendValues([]decimal128.Num{decimal128.FromI64(12345), decimal128.FromI64(-250)}, nil)
+	decimals := db.NewArray()
+	defer decimals.Release()
+
+	bb := array.NewBooleanBuilder(mem)
+	defer bb.Release()
+	bb.AppendValues([]bool{false, true}, nil)
+	booleans := bb.NewArray()
+	defer booleans.Release()
+
+	tests := []struct {
+		name     string
+		values   arrow.Array
+		expected []float64
+	}{
+		{name: "timestamp", values: timestamps, expected: []float64{2000, 1000, 2000}},
+		{name: "decimal128", values: decimals, expected: []float64{-2.5, 123.45, -2.5}},
+		{name: "boolean", values: booleans, expected: []float64{1, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dict := newDictionary(t, mem, []int16{1, 0, 1}, nil, tt.values)
+			defer dict.Release()
+
+			f64, err := anyarrow.NewFloat64(dict)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f64.Release()
+			for i, v := range tt.expected {
+				if got := f64.Value(i); got != v {
+					t.Errorf("value at %d: expected %v, got %v", i, v, got)
+				}
+			}
+		})
+	}
+
+	dict := newDictionary(t, mem, []int16{1, 0, 1}, nil, decimals)
+	defer dict.Release()
+	i64, err := anyarrow.NewInt64(dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer i64.Release()
+	if got := i64.Values(); got[0] != -2 || got[1] != 123 || got[2] != -2 {
+		t.Errorf("expected decimals to be truncated toward zero, got %v", got)
+	}
+}
+
+func TestDictionaryUnsupportedValueType(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+	defer lb.Release()
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Int64Builder).Append(1)
+	list := lb.NewArray()
+	defer list.Release()
+
+	dict := newDictionary(t, mem, []int16{0}, nil, list)
+	defer dict.Release()
+
+	_, err := anyarrow.NewInt64(dict)
+	if err == nil {
+		t.Fatal("expected error for dictionary of list values")
+	}
+	for _, s := range []string{"int16", "list<item: int64, nullable>"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected error %q to contain %q", err, s)
+		}
+	}
+}
+
+func TestDictionaryNullIndices(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	fb := array.NewFloat64Builder(mem)
+	defer fb.Release()
+	fb.AppendValues([]float64{1.5, 2.5}, nil)
+	values := fb.NewArray()
+	defer values.Release()
+
+	// the index of the null slot is undefined, and out of range here.
+	dict := newDictionary(t, mem, []int16{0, 99, 1}, []bool{true, false, true}, values)
+	defer dict.Release()
+
+	expected := []float64{1.5, 0, 2.5}
+	for _, opts := range [][]anyarrow.Option{nil, {anyarrow.WithDecodedDictionary()}} {
+		f64, err := anyarrow.NewFloat64(dict, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f64.Release()
+
+		if got := f64.Values(); !slices.Equal(got, expected) {
+			t.Errorf("expected values %v, got %v", expected, got)
+		}
+		sliced := f64.Slice(1, 3)
+		defer sliced.Release()
+		dst := make([]float64, 2)
+		if n := sliced.CopyTo(dst); n != 2 || !slices.Equal(dst, expected[1:]) {
+			t.Errorf("expected CopyTo of slice to copy %v, got %v", expected[1:], dst[:n])
+		}
+
+		for i, v := range f64.All() {
+			if v != expected[i] {
+				t.Errorf("All at %d: expected %v, got %v", i, expected[i], v)
+			}
+		}
+		for i, v := range f64.AllNullable() {
+			if v.Valid != (i != 1) || v.Value != expected[i] {
+				t.Errorf("AllNullable at %d: expected %v, got %v", i, expected[i], v)
+			}
+		}
+		for start, chunk := range f64.Chunks(2) {
+			if !slices.Equal(chunk, expected[start:min(start+2, len(expected))]) {
+				t.Errorf("chunk at %d: expected %v, got %v", start, expected[start:min(start+2, len(expected))], chunk)
+			}
+		}
+	}
+}
@@ -5,7 +5,43 @@
 // which implements the [arrow.Array] interface and have a [Value] function
 // that returns an int8 by performing the proper cast.
 //
-// arrow's dictionary, which is categorical data, is also supported.
+// arrow's dictionary, which is categorical data, is also supported,
+// and its values can be of any type that the accessor accepts.
+// Every accessor also accepts arrow's null arrays, such as columns missing from some files of a unified schema,
+// reading all their elements as null.
+//
+// Each accessor also has a generic counterpart, for example [Float64Of] and [NewFloat64Of],
+// whose Value returns a user-defined type with the same underlying type,
+// such as
+//
+//	type Price float64
+//
+//	prices, err := anyarrow.NewFloat64Of[Price](a)
+//
+// For generic tooling, [Any] reads arrays of any type, including nested and encoded ones, as go values.
+// [BigInt] reads integers, integral decimals and numeric strings exactly as [big.Int].
+// [Interval] reads the interval arrays as [IntervalValue], which can be applied to a [time.Time].
+//
+// Accessors read directly from the buffers of the array, and strings returned by [String]
+// share the memory of the array, even when the array is binary.
+//
+// An accessor holds a reference to its array: the NewXxx functions retain the array,
+// and the array is released when the accessor's reference count drops to zero.
+// The caller can therefore release its own reference to the array right after creating the accessor,
+// and must release the accessor when done.
+//
+// In the other direction, an [Appender] appends go values to any compatible [array.Builder],
+// converting them to the type of the builder the same way as the accessors convert the values they read.
+//
+// Records can also be converted from and to slices of go structs with [Unmarshal] and [Marshal],
+// which map the fields of the structs to the columns by their `arrow:"name"` tags.
+// For hot paths, cmd/codecgen generates the equivalent functions for a struct type without reflection.
+//
+// Conversion failures are reported as typed errors, such as [UnsupportedTypeError] and [OverflowError],
+// which carry the arrow and go types involved and can be inspected with [errors.As].
+// [CanConvert] checks ahead whether an arrow type can be read as a go type, and whether the conversion loses information,
+// [CheckSchema] applies the same rules to every column of a schema expected by a [Contract],
+// and the Explain method of an accessor describes how its elements are read.
 package anyarrow
 
 //go:generate go run ./cmd/gen
@@ -0,0 +1,521 @@
+package anyarrow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fardream/anyarrow/internal/tag"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// Marshal encodes rows into an [arrow.Record] allocated by mem, which is the reverse of [Unmarshal].
+//
+// The exported fields of T are mapped to the columns by the same `arrow:"name"` tags as [NewDecoder],
+// and the arrow type of a column is derived from the go type of its field:
+//   - bool, integers, floats and strings are encoded as the arrow types of the same size,
+//     int and uint are encoded as int64 and uint64.
+//   - []byte is encoded as binary.
+//   - time.Time is encoded as timestamp, and time.Duration as duration.
+//   - structs are encoded as struct columns, and other slices as list columns.
+//   - pointers and [Nullable] are encoded as their element types, with nil and invalid values as nulls.
+//     nil slices are also encoded as nulls.
+//
+// The options following the name in the tag change the arrow type of the column:
+//   - dict: encodes string and []byte fields as dictionaries with int32 indices.
+//   - unit=s|ms|us|ns: the unit of time.Time and time.Duration fields, nanosecond by default.
+//   - tz=zone: the time zone of time.Time fields, UTC by default.
+//   - date32, date64: encodes time.Time fields as dates instead of timestamps.
+//   - scale=n, precision=n: encodes integer and float fields as decimal128, precision is 38 by default.
+//
+// For example
+//
+//	type Trade struct {
+//		Symbol string    `arrow:"symbol,dict"`
+//		Price  float64   `arrow:"price,scale=4"`
+//		Time   time.Time `arrow:"time,unit=ms"`
+//	}
+//
+// The options of pointers, [Nullable] and slices apply to their elements.
+// mem is used for all the buffers of the record, so a pooled allocator can be used to reuse the memory of released records.
+// The caller must release the returned record.
+func Marshal[T any](mem memory.Allocator, rows []T) (arrow.Record, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot encode %s into records, which is not a struct", t)
+	}
+
+	st, fields, err := newStructEncoder(t)
+	if err != nil {
+		return nil, err
+	}
+
+	b := array.NewRecordBuilder(mem, arrow.NewSchema(st.dt.Fields(), nil))
+	defer b.Release()
+	b.Reserve(len(rows))
+
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		for k, f := range fields {
+			if err := encodeFrom(f.enc, b.Field(k), fieldByIndexOrZero(v, f)); err != nil {
+				return nil, fmt.Errorf("cannot encode row %d field %s.%s: %w", i, t, fieldName(t, f.index), err)
+			}
+		}
+	}
+
+	return b.NewRecord(), nil
+}
+
+// encoder appends go values to arrow builders.
+type encoder interface {
+	dataType() arrow.DataType
+	// nullable checks if the encoder can append nulls.
+	nullable() bool
+	// isNull checks if v should be appended as null.
+	isNull(v reflect.Value) bool
+	// encode appends v, which is not null, to b.
+	encode(b array.Builder, v reflect.Value) error
+}
+
+// encodeFrom appends v to b, or a null if v is null.
+func encodeFrom(e encoder, b array.Builder, v reflect.Value) error {
+	if e.isNull(v) {
+		b.AppendNull()
+		return nil
+	}
+
+	return e.encode(b, v)
+}
+
+type fieldEncoder struct {
+	structField
+	enc encoder
+}
+
+// fieldByIndexOrZero returns the field f of struct v, or the zero value of the field if it is in a nil embedded struct.
+func fieldByIndexOrZero(v reflect.Value, f fieldEncoder) reflect.Value {
+	fv, err := v.FieldByIndexErr(f.index)
+	if err != nil {
+		return reflect.Zero(f.typ)
+	}
+
+	return fv
+}
+
+// newEncoder creates the encoder for go values of type t with tag options.
+func newEncoder(t reflect.Type, o tag.Options) (encoder, error) {
+	switch {
+	case t.Kind() == reflect.Pointer:
+		elem, err := newEncoder(t.Elem(), o)
+		if err != nil {
+			return nil, err
+		}
+		return &pointerEncoder{elem: elem}, nil
+	case isNullable(t):
+		elem, err := newEncoder(t.Field(0).Type, o)
+		if err != nil {
+			return nil, err
+		}
+		return &nullableEncoder{elem: elem}, nil
+	case t == timeType:
+		return newTimeEncoder(o), nil
+	case t == durationType:
+		unit := int64(o.Unit.Multiplier())
+		return newValueEncoder(&arrow.DurationType{Unit: o.Unit}, func(b *array.DurationBuilder, v reflect.Value) {
+			b.Append(arrow.Duration(v.Int() / unit))
+		}), nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		// the options apply to the elements, so lists are handled before the dictionary and decimal options.
+		elem, err := newEncoder(t.Elem(), o)
+		if err != nil {
+			return nil, err
+		}
+		return &listEncoder{elem: elem}, nil
+	case o.Dictionary:
+		if t.Kind() != reflect.String && (t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8) {
+			return nil, fmt.Errorf("cannot dictionary encode %s", t)
+		}
+		return newDictionaryEncoder(t), nil
+	case o.Decimal:
+		return newDecimalEncoder(t, o)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &sliceEncoder{encoder: newValueEncoder(arrow.BinaryTypes.Binary, func(b *array.BinaryBuilder, v reflect.Value) {
+			b.Append(v.Bytes())
+		})}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return newValueEncoder(arrow.FixedWidthTypes.Boolean, func(b *array.BooleanBuilder, v reflect.Value) {
+			b.Append(v.Bool())
+		}), nil
+	case reflect.Int8:
+		return newValueEncoder(arrow.PrimitiveTypes.Int8, func(b *array.Int8Builder, v reflect.Value) {
+			b.Append(int8(v.Int()))
+		}), nil
+	case reflect.Int16:
+		return newValueEncoder(arrow.PrimitiveTypes.Int16, func(b *array.Int16Builder, v reflect.Value) {
+			b.Append(int16(v.Int()))
+		}), nil
+	case reflect.Int32:
+		return newValueEncoder(arrow.PrimitiveTypes.Int32, func(b *array.Int32Builder, v reflect.Value) {
+			b.Append(int32(v.Int()))
+		}), nil
+	case reflect.Int, reflect.Int64:
+		return newValueEncoder(arrow.PrimitiveTypes.Int64, func(b *array.Int64Builder, v reflect.Value) {
+			b.Append(v.Int())
+		}), nil
+	case reflect.Uint8:
+		return newValueEncoder(arrow.PrimitiveTypes.Uint8, func(b *array.Uint8Builder, v reflect.Value) {
+			b.Append(uint8(v.Uint()))
+		}), nil
+	case reflect.Uint16:
+		return newValueEncoder(arrow.PrimitiveTypes.Uint16, func(b *array.Uint16Builder, v reflect.Value) {
+			b.Append(uint16(v.Uint()))
+		}), nil
+	case reflect.Uint32:
+		return newValueEncoder(arrow.PrimitiveTypes.Uint32, func(b *array.Uint32Builder, v reflect.Value) {
+			b.Append(uint32(v.Uint()))
+		}), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return newValueEncoder(arrow.PrimitiveTypes.Uint64, func(b *array.Uint64Builder, v reflect.Value) {
+			b.Append(v.Uint())
+		}), nil
+	case reflect.Float32:
+		return newValueEncoder(arrow.PrimitiveTypes.Float32, func(b *array.Float32Builder, v reflect.Value) {
+			b.Append(float32(v.Float()))
+		}), nil
+	case reflect.Float64:
+		return newValueEncoder(arrow.PrimitiveTypes.Float64, func(b *array.Float64Builder, v reflect.Value) {
+			b.Append(v.Float())
+		}), nil
+	case reflect.String:
+		return newValueEncoder(arrow.BinaryTypes.String, func(b *array.StringBuilder, v reflect.Value) {
+			b.Append(v.String())
+		}), nil
+	case reflect.Struct:
+		st, _, err := newStructEncoder(t)
+		if err != nil {
+			return nil, err
+		}
+		return st, nil
+	default:
+		return nil, fmt.Errorf("unsupported go type %s", t)
+	}
+}
+
+// valueEncoder encodes go values by appending them to builders of type B.
+type valueEncoder[B array.Builder] struct {
+	dt          arrow.DataType
+	appendValue func(b B, v reflect.Value)
+}
+
+func newValueEncoder[B array.Builder](dt arrow.DataType, appendValue func(B, reflect.Value)) *valueEncoder[B] {
+	return &valueEncoder[B]{dt: dt, appendValue: appendValue}
+}
+
+func (e *valueEncoder[B]) dataType() arrow.DataType {
+	return e.dt
+}
+
+func (e *valueEncoder[B]) nullable() bool {
+	return false
+}
+
+func (e *valueEncoder[B]) isNull(reflect.Value) bool {
+	return false
+}
+
+func (e *valueEncoder[B]) encode(b array.Builder, v reflect.Value) error {
+	e.appendValue(b.(B), v)
+	return nil
+}
+
+// sliceEncoder encodes slices that are encoded as single values, such as []byte, with nil slices as nulls.
+type sliceEncoder struct {
+	encoder
+}
+
+func (e *sliceEncoder) nullable() bool {
+	return true
+}
+
+func (e *sliceEncoder) isNull(v reflect.Value) bool {
+	return v.IsNil()
+}
+
+// newTimeEncoder creates the encoder for time.Time.
+func newTimeEncoder(o tag.Options) encoder {
+	switch o.Date.(type) {
+	case *arrow.Date32Type:
+		return newValueEncoder(o.Date, func(b *array.Date32Builder, v reflect.Value) {
+			b.Append(arrow.Date32FromTime(v.Interface().(time.Time)))
+		})
+	case *arrow.Date64Type:
+		return newValueEncoder(o.Date, func(b *array.Date64Builder, v reflect.Value) {
+			b.Append(arrow.Date64FromTime(v.Interface().(time.Time)))
+		})
+	}
+
+	return &timestampEncoder{dt: &arrow.TimestampType{Unit: o.Unit, TimeZone: o.TimeZone}}
+}
+
+// timestampEncoder encodes time.Time as timestamps.
+type timestampEncoder struct {
+	dt *arrow.TimestampType
+}
+
+func (e *timestampEncoder) dataType() arrow.DataType {
+	return e.dt
+}
+
+func (e *timestampEncoder) nullable() bool {
+	return false
+}
+
+func (e *timestampEncoder) isNull(reflect.Value) bool {
+	return false
+}
+
+func (e *timestampEncoder) encode(b array.Builder, v reflect.Value) error {
+	ts, err := arrow.TimestampFromTime(v.Interface().(time.Time), e.dt.Unit)
+	if err != nil {
+		return err
+	}
+	b.(*array.TimestampBuilder).Append(ts)
+
+	return nil
+}
+
+// dictionaryEncoder encodes string and []byte as dictionaries.
+type dictionaryEncoder struct {
+	dt    *arrow.DictionaryType
+	bytes bool
+}
+
+func newDictionaryEncoder(t reflect.Type) encoder {
+	if t.Kind() == reflect.String {
+		return &dictionaryEncoder{dt: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}}
+	}
+
+	return &sliceEncoder{encoder: &dictionaryEncoder{
+		dt:    &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.Binary},
+		bytes: true,
+	}}
+}
+
+func (e *dictionaryEncoder) dataType() arrow.DataType {
+	return e.dt
+}
+
+func (e *dictionaryEncoder) nullable() bool {
+	return false
+}
+
+func (e *dictionaryEncoder) isNull(reflect.Value) bool {
+	return false
+}
+
+func (e *dictionaryEncoder) encode(b array.Builder, v reflect.Value) error {
+	if e.bytes {
+		return b.(*array.BinaryDictionaryBuilder).Append(v.Bytes())
+	}
+
+	return b.(*array.BinaryDictionaryBuilder).AppendString(v.String())
+}
+
+// decimalEncoder encodes integers and floats as decimal128.
+type decimalEncoder struct {
+	dt *arrow.Decimal128Type
+	// toDecimal converts v to a decimal with the precision and scale of dt, and reports whether v fits in the precision.
+	toDecimal func(v reflect.Value) (decimal128.Num, bool)
+}
+
+func newDecimalEncoder(t reflect.Type, o tag.Options) (encoder, error) {
+	dt := &arrow.Decimal128Type{Precision: o.Precision, Scale: o.Scale}
+	e := &decimalEncoder{dt: dt}
+	fromInt := func(n decimal128.Num) (decimal128.Num, bool) {
+		n = n.IncreaseScaleBy(dt.Scale)
+		return n, n.FitsInPrecision(dt.Precision)
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.toDecimal = func(v reflect.Value) (decimal128.Num, bool) {
+			return fromInt(decimal128.FromI64(v.Int()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.toDecimal = func(v reflect.Value) (decimal128.Num, bool) {
+			return fromInt(decimal128.FromU64(v.Uint()))
+		}
+	case reflect.Float32, reflect.Float64:
+		e.toDecimal = func(v reflect.Value) (decimal128.Num, bool) {
+			n, err := decimal128.FromFloat64(v.Float(), dt.Precision, dt.Scale)
+			return n, err == nil
+		}
+	default:
+		return nil, &UnsupportedTypeError{DataType: dt, GoType: t}
+	}
+
+	return e, nil
+}
+
+func (e *decimalEncoder) dataType() arrow.DataType {
+	return e.dt
+}
+
+func (e *decimalEncoder) nullable() bool {
+	return false
+}
+
+func (e *decimalEncoder) isNull(reflect.Value) bool {
+	return false
+}
+
+func (e *decimalEncoder) encode(b array.Builder, v reflect.Value) error {
+	n, ok := e.toDecimal(v)
+	if !ok {
+		return &OverflowError{DataType: e.dt, GoType: v.Type(), Index: b.Len(), Value: v.Interface()}
+	}
+	b.(*array.Decimal128Builder).Append(n)
+
+	return nil
+}
+
+// pointerEncoder encodes pointers, with nil pointers as nulls.
+type pointerEncoder struct {
+	elem encoder
+}
+
+func (e *pointerEncoder) dataType() arrow.DataType {
+	return e.elem.dataType()
+}
+
+func (e *pointerEncoder) nullable() bool {
+	return true
+}
+
+func (e *pointerEncoder) isNull(v reflect.Value) bool {
+	return v.IsNil() || e.elem.isNull(v.Elem())
+}
+
+func (e *pointerEncoder) encode(b array.Builder, v reflect.Value) error {
+	return e.elem.encode(b, v.Elem())
+}
+
+// nullableEncoder encodes [Nullable], with invalid values as nulls.
+type nullableEncoder struct {
+	elem encoder
+}
+
+func (e *nullableEncoder) dataType() arrow.DataType {
+	return e.elem.dataType()
+}
+
+func (e *nullableEncoder) nullable() bool {
+	return true
+}
+
+func (e *nullableEncoder) isNull(v reflect.Value) bool {
+	return !v.Field(1).Bool() || e.elem.isNull(v.Field(0))
+}
+
+func (e *nullableEncoder) encode(b array.Builder, v reflect.Value) error {
+	return e.elem.encode(b, v.Field(0))
+}
+
+// listEncoder encodes slices as lists, with nil slices as nulls.
+type listEncoder struct {
+	elem encoder
+}
+
+func (e *listEncoder) dataType() arrow.DataType {
+	return arrow.ListOfField(arrow.Field{Name: "item", Type: e.elem.dataType(), Nullable: e.elem.nullable()})
+}
+
+func (e *listEncoder) nullable() bool {
+	return true
+}
+
+func (e *listEncoder) isNull(v reflect.Value) bool {
+	return v.IsNil()
+}
+
+func (e *listEncoder) encode(b array.Builder, v reflect.Value) error {
+	lb := b.(*array.ListBuilder)
+	lb.Append(true)
+	vb := lb.ValueBuilder()
+	for j := range v.Len() {
+		if err := encodeFrom(e.elem, vb, v.Index(j)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// structEncoder encodes structs as struct columns.
+type structEncoder struct {
+	dt     *arrow.StructType
+	fields []fieldEncoder
+}
+
+// newStructEncoder creates the encoder for struct type t, and returns the encoders of its fields.
+func newStructEncoder(t reflect.Type) (*structEncoder, []fieldEncoder, error) {
+	var fields []fieldEncoder
+	var arrowFields []arrow.Field
+	var errs []error
+	for _, sf := range structFields(t) {
+		enc, err := newFieldEncoder(sf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot encode field %s.%s into column %q: %w", t, fieldName(t, sf.index), sf.name, err))
+			continue
+		}
+		fields = append(fields, fieldEncoder{structField: sf, enc: enc})
+		arrowFields = append(arrowFields, arrow.Field{Name: sf.name, Type: enc.dataType(), Nullable: enc.nullable()})
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return &structEncoder{dt: arrow.StructOf(arrowFields...), fields: fields}, fields, nil
+}
+
+func newFieldEncoder(sf structField) (encoder, error) {
+	o, err := tag.ParseOptions(sf.options)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEncoder(sf.typ, o)
+}
+
+func (e *structEncoder) dataType() arrow.DataType {
+	return e.dt
+}
+
+func (e *structEncoder) nullable() bool {
+	return false
+}
+
+func (e *structEncoder) isNull(reflect.Value) bool {
+	return false
+}
+
+func (e *structEncoder) encode(b array.Builder, v reflect.Value) error {
+	sb := b.(*array.StructBuilder)
+	sb.Append(true)
+	for k, f := range e.fields {
+		if err := encodeFrom(f.enc, sb.FieldBuilder(k), fieldByIndexOrZero(v, f)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
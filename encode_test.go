@@ -0,0 +1,188 @@
+package anyarrow_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// EncodeMeta is exported so that it can be embedded by pointer.
+type EncodeMeta struct {
+	Source string `arrow:"source"`
+}
+
+type encodeRow struct {
+	*EncodeMeta
+	Symbol  string                    `arrow:"symbol,dict"`
+	Time    time.Time                 `arrow:"time,unit=ms,tz=America/New_York"`
+	Date    time.Time                 `arrow:"date,date32"`
+	Price   float64                   `arrow:"price,precision=10,scale=2"`
+	Size    *int32                    `arrow:"size"`
+	Venue   anyarrow.Nullable[string] `arrow:"venue"`
+	Flag    bool                      `arrow:"flag"`
+	Quote   *decodeQuote              `arrow:"quote"`
+	Fills   []int64                   `arrow:"fills"`
+	Latency time.Duration             `arrow:"latency,unit=us"`
+	Raw     []byte                    `arrow:"raw"`
+	Ignored string                    `arrow:"-"`
+}
+
+func TestMarshal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	size := int32(10)
+	rows := []encodeRow{
+		{
+			EncodeMeta: &EncodeMeta{Source: "a"},
+			Symbol:     "X",
+			Time:       time.UnixMilli(1000).UTC(),
+			Date:       time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Price:      1.25,
+			Size:       &size,
+			Venue:      anyarrow.Nullable[string]{Value: "N", Valid: true},
+			Flag:       true,
+			Quote:      &decodeQuote{Bid: 1, Ask: 2},
+			Fills:      []int64{1, 2},
+			Latency:    3 * time.Millisecond,
+			Raw:        []byte("raw"),
+		},
+		{
+			Symbol:  "X",
+			Time:    time.UnixMilli(2000).UTC(),
+			Date:    time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Price:   2.5,
+			Fills:   []int64{},
+			Latency: 5 * time.Microsecond,
+			Ignored: "ignored",
+		},
+	}
+
+	rec, err := anyarrow.Marshal(mem, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	expectedSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "source", Type: arrow.BinaryTypes.String},
+		{Name: "symbol", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}},
+		{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "America/New_York"}},
+		{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{Name: "size", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "venue", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "flag", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "quote", Type: arrow.StructOf(
+			arrow.Field{Name: "bid", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "ask", Type: arrow.PrimitiveTypes.Float64},
+		), Nullable: true},
+		{Name: "fills", Type: arrow.ListOfNonNullable(arrow.PrimitiveTypes.Int64), Nullable: true},
+		{Name: "latency", Type: arrow.FixedWidthTypes.Duration_us},
+		{Name: "raw", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	}, nil)
+	if !rec.Schema().Equal(expectedSchema) {
+		t.Fatalf("expected schema %s, got %s", expectedSchema, rec.Schema())
+	}
+	if rec.NumRows() != int64(len(rows)) {
+		t.Fatalf("expected %d rows, got %d", len(rows), rec.NumRows())
+	}
+
+	var decoded []encodeRow
+	if err := anyarrow.Unmarshal(rec, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	rows[1].EncodeMeta = &EncodeMeta{}
+	rows[1].Ignored = ""
+	for i := range rows {
+		// compare times by instant as the decoded times are in the time zone of the column.
+		if !decoded[i].Time.Equal(rows[i].Time) {
+			t.Errorf("row %d: expected time %s, got %s", i, rows[i].Time, decoded[i].Time)
+		}
+		decoded[i].Time = rows[i].Time
+	}
+	if !reflect.DeepEqual(decoded, rows) {
+		t.Errorf("expected %+v, got %+v", rows, decoded)
+	}
+}
+
+func TestMarshalListOptions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	type row struct {
+		Tags   []string  `arrow:"tags,dict"`
+		Prices []float64 `arrow:"prices,scale=2"`
+		Sizes  []*int64  `arrow:"sizes,precision=10,scale=1"`
+		Raws   [][]byte  `arrow:"raws,dict"`
+	}
+	size := int64(3)
+	rows := []row{
+		{Tags: []string{"a", "b"}, Prices: []float64{1.25}, Sizes: []*int64{&size, nil}, Raws: [][]byte{[]byte("x")}},
+		{},
+	}
+
+	rec, err := anyarrow.Marshal(mem, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	expectedTypes := []arrow.DataType{
+		arrow.ListOfNonNullable(&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}),
+		arrow.ListOfNonNullable(&arrow.Decimal128Type{Precision: 38, Scale: 2}),
+		arrow.ListOf(&arrow.Decimal128Type{Precision: 10, Scale: 1}),
+		arrow.ListOf(&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.Binary}),
+	}
+	for i, dt := range expectedTypes {
+		if f := rec.Schema().Field(i); !arrow.TypeEqual(f.Type, dt) {
+			t.Errorf("column %s: expected type %s, got %s", f.Name, dt, f.Type)
+		}
+	}
+
+	var decoded []row
+	if err := anyarrow.Unmarshal(rec, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded[0], rows[0]) {
+		t.Errorf("expected %+v, got %+v", rows[0], decoded[0])
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	type invalid struct {
+		Dict  int64          `arrow:"dict,dict"`
+		Scale string         `arrow:"scale,scale=2"`
+		Unit  time.Duration  `arrow:"unit,unit=h"`
+		Chan  chan int       `arrow:"chan"`
+		Other int            `arrow:"other,unknown"`
+		Map   map[string]int `arrow:"map"`
+		Neg   int64          `arrow:"neg,scale=-2"`
+	}
+
+	_, err := anyarrow.Marshal(mem, []invalid{{}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, s := range []string{"Dict", "Scale", "Unit", "Chan", "Other", "Map", "Neg"} {
+		if !strings.Contains(err.Error(), "field anyarrow_test.invalid."+s+" ") {
+			t.Errorf("expected error %q to mention field %s", err, s)
+		}
+	}
+
+	type overflow struct {
+		Price int64 `arrow:"price,precision=3,scale=2"`
+	}
+	if _, err := anyarrow.Marshal(mem, []overflow{{Price: 1}, {Price: 10}}); err == nil || !strings.Contains(err.Error(), "row 1") {
+		t.Errorf("expected overflow error at row 1, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package anyarrow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+// UnsupportedTypeError is returned when the values of an arrow type cannot be converted to or from a go type,
+// for example when creating an [Int64] from a string array, or an [Appender] of strings for an int64 builder.
+//
+// It matches [errors.ErrUnsupported] with [errors.Is].
+type UnsupportedTypeError struct {
+	// DataType is the arrow type of the array or the builder.
+	DataType arrow.DataType
+	// GoType is the go type of the values.
+	GoType reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("cannot use %s for gotype %s", e.DataType, e.GoType)
+}
+
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == errors.ErrUnsupported
+}
+
+// UnsupportedDictionaryError is returned when a dictionary cannot be read as a go type,
+// because either the type of its indices or the type of its values is not supported.
+//
+// It matches [errors.ErrUnsupported] with [errors.Is].
+type UnsupportedDictionaryError struct {
+	// DataType is the arrow type of the dictionary.
+	DataType *arrow.DictionaryType
+	// GoType is the go type of the values.
+	GoType reflect.Type
+}
+
+func (e *UnsupportedDictionaryError) Error() string {
+	return fmt.Sprintf("cannot use dictionary with %s indices and %s values for gotype %s", e.DataType.IndexType, e.DataType.ValueType, e.GoType)
+}
+
+func (e *UnsupportedDictionaryError) Is(target error) bool {
+	return target == errors.ErrUnsupported
+}
+
+// DictionaryMismatchError is returned when the data type of a dictionary array disagrees with its data,
+// either because it is not a [arrow.DictionaryType], or because its value type is not the type of the dictionary values.
+type DictionaryMismatchError struct {
+	// DataType is the data type of the dictionary array.
+	DataType arrow.DataType
+	// ValueType is the data type of the dictionary values, nil if DataType is not a dictionary type.
+	ValueType arrow.DataType
+	// GoType is the go type of the values.
+	GoType reflect.Type
+}
+
+func (e *DictionaryMismatchError) Error() string {
+	if e.ValueType == nil {
+		return fmt.Sprintf("cannot use dictionary of %s for gotype %s: not a dictionary type", e.DataType, e.GoType)
+	}
+	return fmt.Sprintf("cannot use dictionary of %s with %s values for gotype %s: mismatched types", e.DataType, e.ValueType, e.GoType)
+}
+
+// OverflowError is returned when a value cannot be represented by the arrow type or go type it is converted to,
+// for example a float that overflows the precision of a decimal builder,
+// or an element of an int64 array that is out of the range of an [Int32] when validated.
+type OverflowError struct {
+	// DataType is the arrow type of the array or the builder.
+	DataType arrow.DataType
+	// GoType is the go type of the values.
+	GoType reflect.Type
+	// Index is the index of the value in the array or the builder.
+	Index int
+	// Value is the value that overflows, which is formatted by [arrow.Array.ValueStr] for values read from arrays.
+	Value any
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %v at index %d overflows the conversion between %s and gotype %s", e.Value, e.Index, e.DataType, e.GoType)
+}
+
+// LossyConversionError is returned by the accessors created with [WithLossless]
+// for arrays whose values are narrowed, see [ConversionNarrowing].
+type LossyConversionError struct {
+	Conversion Conversion
+}
+
+func (e *LossyConversionError) Error() string {
+	return fmt.Sprintf("cannot use %s for gotype %s without loss", e.Conversion.DataType, e.Conversion.GoType)
+}
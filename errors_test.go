@@ -0,0 +1,132 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestUnsupportedTypeError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	strs := anyarrow.FromStrings(mem, []string{"a", "b"}, nil)
+	defer strs.Release()
+
+	_, err := anyarrow.NewFloat64Of[Price](strs)
+	var typeErr *anyarrow.UnsupportedTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected UnsupportedTypeError, got %v", err)
+	}
+	if !arrow.TypeEqual(typeErr.DataType, arrow.BinaryTypes.String) || typeErr.GoType != reflect.TypeFor[Price]() {
+		t.Errorf("unexpected error fields %s and %s", typeErr.DataType, typeErr.GoType)
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Error("expected error to match errors.ErrUnsupported")
+	}
+	if expected := "cannot use utf8 for gotype anyarrow_test.Price"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err)
+	}
+
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+	if _, err := anyarrow.NewAppender[string](b); !errors.As(err, &typeErr) {
+		t.Errorf("expected UnsupportedTypeError from NewAppender, got %v", err)
+	}
+}
+
+func TestUnsupportedDictionaryError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	strs := anyarrow.FromStrings(mem, []string{"a"}, nil)
+	defer strs.Release()
+	dict := newDictionary(t, mem, []int16{0, 0}, nil, strs)
+	defer dict.Release()
+
+	_, err := anyarrow.NewInt64(dict)
+	var dictErr *anyarrow.UnsupportedDictionaryError
+	if !errors.As(err, &dictErr) {
+		t.Fatalf("expected UnsupportedDictionaryError, got %v", err)
+	}
+	if !arrow.TypeEqual(dictErr.DataType, dict.DataType()) || dictErr.GoType != reflect.TypeFor[int64]() {
+		t.Errorf("unexpected error fields %s and %s", dictErr.DataType, dictErr.GoType)
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Error("expected error to match errors.ErrUnsupported")
+	}
+}
+
+func TestDictionaryMismatchError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt16Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int16{0}, nil)
+	indices := ib.NewArray()
+	defer indices.Release()
+	values := anyarrow.FromSlice([]float64{1.5}, nil)
+	defer values.Release()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.PrimitiveTypes.Int64}
+	dict := array.NewDictionaryArray(dt, indices, values)
+	defer dict.Release()
+
+	_, err := anyarrow.NewInt64(dict)
+	var mismatchErr *anyarrow.DictionaryMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected DictionaryMismatchError, got %v", err)
+	}
+	if !arrow.TypeEqual(mismatchErr.ValueType, arrow.PrimitiveTypes.Float64) {
+		t.Errorf("expected float64 values, got %s", mismatchErr.ValueType)
+	}
+}
+
+func TestOverflowError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var overflowErr *anyarrow.OverflowError
+
+	b := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 4, Scale: 2})
+	defer b.Release()
+	a, err := anyarrow.NewAppender[float64](b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.AppendValues([]float64{1.5, 12.25, 100}, nil)
+	if !errors.As(err, &overflowErr) {
+		t.Fatalf("expected OverflowError from Appender, got %v", err)
+	}
+	if overflowErr.Index != 2 || overflowErr.Value != 100.0 || overflowErr.GoType != reflect.TypeFor[float64]() {
+		t.Errorf("unexpected overflow at %d of %v with gotype %s", overflowErr.Index, overflowErr.Value, overflowErr.GoType)
+	}
+
+	type row struct {
+		Price int64 `arrow:"price,precision=3,scale=2"`
+	}
+	_, err = anyarrow.Marshal(mem, []row{{Price: 1}, {Price: 10}})
+	if !errors.As(err, &overflowErr) {
+		t.Fatalf("expected OverflowError from Marshal, got %v", err)
+	}
+	if overflowErr.Index != 1 || overflowErr.Value != int64(10) {
+		t.Errorf("unexpected overflow at %d of %v", overflowErr.Index, overflowErr.Value)
+	}
+
+	times := []time.Time{time.Unix(0, 0), {}}
+	_, err = anyarrow.FromTimes(mem, times, nil, arrow.FixedWidthTypes.Timestamp_ns)
+	if !errors.As(err, &overflowErr) {
+		t.Fatalf("expected OverflowError from FromTimes, got %v", err)
+	}
+	if overflowErr.Index != 1 || overflowErr.GoType != reflect.TypeFor[time.Time]() {
+		t.Errorf("unexpected overflow at %d with gotype %s", overflowErr.Index, overflowErr.GoType)
+	}
+}
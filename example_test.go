@@ -2,6 +2,7 @@ package anyarrow_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fardream/anyarrow"
 
@@ -26,12 +27,17 @@ func Example() {
 	if err != nil {
 		panic(err)
 	}
+	defer u64.Release()
 
 	for i := 0; i < 5; i++ {
 		fmt.Println(u64.Value(i))
 	}
 
 	f32, err := anyarrow.NewFloat32(a)
+	if err != nil {
+		panic(err)
+	}
+	defer f32.Release()
 	if !f32.IsDirect() {
 		panic("f32 is not direct")
 	}
@@ -81,6 +87,7 @@ func Example_dictionary() {
 	if err != nil {
 		panic(err)
 	}
+	defer int64array.Release()
 
 	fmt.Printf("len: %d\n", int64array.Len())
 	for i := 0; i < 5; i++ {
@@ -132,6 +139,7 @@ func Example_string() {
 	if err != nil {
 		panic(err)
 	}
+	defer stringarray.Release()
 
 	fmt.Printf("len: %d\n", stringarray.Len())
 	for i := 0; i < 5; i++ {
@@ -153,3 +161,190 @@ func Example_string() {
 	// def
 	// dict len: 2
 }
+
+type Price float64
+
+func Example_namedType() {
+	mem := memory.NewGoAllocator()
+	ab := array.NewInt32Builder(mem)
+	defer ab.Release()
+
+	ab.AppendValues([]int32{100, 101, 99}, nil)
+
+	a := ab.NewArray()
+	defer a.Release()
+
+	prices, err := anyarrow.NewFloat64Of[Price](a)
+	if err != nil {
+		panic(err)
+	}
+	defer prices.Release()
+
+	for i := 0; i < prices.Len(); i++ {
+		var p Price = prices.Value(i)
+		fmt.Println(p)
+	}
+
+	// Output: 100
+	// 101
+	// 99
+}
+
+func Example_values() {
+	mem := memory.NewGoAllocator()
+	ab := array.NewInt64Builder(mem)
+	defer ab.Release()
+
+	ab.AppendValues([]int64{1, -1, 2, 3}, nil)
+
+	a := ab.NewArray()
+	defer a.Release()
+
+	// uint64 has the same bit representation as int64, so Values doesn't copy.
+	u64, err := anyarrow.NewUint64(a)
+	if err != nil {
+		panic(err)
+	}
+	defer u64.Release()
+	fmt.Println(u64.Values())
+
+	f64, err := anyarrow.NewFloat64(a)
+	if err != nil {
+		panic(err)
+	}
+	defer f64.Release()
+	dst := make([]float64, 2)
+	n := f64.CopyTo(dst)
+	fmt.Println(n, dst)
+	fmt.Println(f64.AppendValues(dst))
+
+	// Output: [1 18446744073709551615 2 3]
+	// 2 [1 -1]
+	// [1 -1 1 -1 2 3]
+}
+
+func Example_iterate() {
+	mem := memory.NewGoAllocator()
+	ab := array.NewInt16Builder(mem)
+	defer ab.Release()
+
+	ab.AppendValues([]int16{1, 2, 0, 4, 5}, []bool{true, true, false, true, true})
+
+	a := ab.NewArray()
+	defer a.Release()
+
+	i32, err := anyarrow.NewInt32(a)
+	if err != nil {
+		panic(err)
+	}
+	defer i32.Release()
+
+	for i, v := range i32.Valid() {
+		fmt.Println(i, v)
+	}
+
+	for i, v := range i32.AllNullable() {
+		fmt.Println(i, v.Value, v.Valid)
+	}
+
+	for start, chunk := range i32.Chunks(2) {
+		fmt.Println(start, chunk)
+	}
+
+	// Output: 0 1
+	// 1 2
+	// 3 4
+	// 4 5
+	// 0 1 true
+	// 1 2 true
+	// 2 0 false
+	// 3 4 true
+	// 4 5 true
+	// 0 [1 2]
+	// 2 [0 4]
+	// 4 [5]
+}
+
+func Example_dictionaryIndex() {
+	mem := memory.NewGoAllocator()
+
+	ab := array.NewDictionaryBuilder(mem, &arrow.DictionaryType{
+		ValueType: &arrow.StringType{},
+		IndexType: &arrow.Int8Type{},
+	})
+	defer ab.Release()
+
+	abb, ok := ab.(*array.BinaryDictionaryBuilder)
+	if !ok {
+		panic("not correct dictionary builder type")
+	}
+
+	for _, s := range []string{"buy", "sell", "sell", "buy"} {
+		if err := abb.AppendString(s); err != nil {
+			panic(err)
+		}
+	}
+
+	dictarray := abb.NewArray()
+	defer dictarray.Release()
+
+	sides, err := anyarrow.NewString(dictarray, anyarrow.WithDecodedDictionary())
+	if err != nil {
+		panic(err)
+	}
+	defer sides.Release()
+
+	for j := 0; j < sides.DictionaryLen(); j++ {
+		fmt.Println(j, sides.DictValue(j))
+	}
+
+	for i := 0; i < sides.Len(); i++ {
+		fmt.Println(sides.Index(i), sides.Value(i))
+	}
+
+	// Output: 0 buy
+	// 1 sell
+	// 0 buy
+	// 1 sell
+	// 1 sell
+	// 0 buy
+}
+
+type Trade struct {
+	Symbol string                    `arrow:"symbol"`
+	Price  float64                   `arrow:"price"`
+	Size   *int32                    `arrow:"size"`
+	Venue  anyarrow.Nullable[string] `arrow:"venue"`
+}
+
+func ExampleUnmarshal() {
+	mem := memory.NewGoAllocator()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "symbol", Type: arrow.BinaryTypes.String},
+		{Name: "price", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "size", Type: arrow.PrimitiveTypes.Uint16, Nullable: true},
+		{Name: "venue", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(`[
+		{"symbol": "A", "price": 10, "size": 100, "venue": "X"},
+		{"symbol": "B", "price": 20, "size": null, "venue": null}
+	]`))
+	if err != nil {
+		panic(err)
+	}
+	defer rec.Release()
+
+	var trades []Trade
+	if err := anyarrow.Unmarshal(rec, &trades); err != nil {
+		panic(err)
+	}
+
+	for _, trade := range trades {
+		fmt.Println(trade.Symbol, trade.Price, trade.Size != nil, trade.Venue.Valid)
+	}
+
+	// Output: A 10 true true
+	// B 20 false false
+}
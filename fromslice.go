@@ -0,0 +1,205 @@
+package anyarrow
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/bitutil"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// FixedWidth is the set of go types whose slices can be wrapped into arrow arrays by [FromSlice].
+type FixedWidth interface {
+	number | ~int | ~uint
+}
+
+// FromSlice creates an arrow array that wraps the memory of vs without copying,
+// where vs[i] is null if valid is not nil and valid[i] is false.
+//
+// The arrow type of the array is the fixed width type of the same size as T, such as int64 for int on 64 bit platforms,
+// except for time.Duration, which is duration[ns].
+//
+// The array shares the memory with vs, so vs must not be modified while the array,
+// or any array sliced from or sharing buffers with it, is in use.
+// The array is released as usual, but releasing it doesn't free vs, which is still managed by go.
+//
+// valid must be either nil or have the same length as vs, otherwise FromSlice panics.
+func FromSlice[T FixedWidth](vs []T, valid []bool) arrow.Array {
+	checkValid(len(vs), valid)
+
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+	data := memory.NewBufferBytes(unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(vs))), len(vs)*size))
+	bitmap, nulls := validityBitmap(valid)
+
+	d := array.NewData(fixedWidthType[T](), len(vs), []*memory.Buffer{bitmap, data}, nil, nulls, 0)
+	defer d.Release()
+
+	return array.MakeFromData(d)
+}
+
+// fixedWidthType is the arrow type of the arrays created by [FromSlice] from slices of T.
+func fixedWidthType[T FixedWidth]() arrow.DataType {
+	t := reflect.TypeFor[T]()
+	if t == durationType {
+		return arrow.FixedWidthTypes.Duration_ns
+	}
+
+	switch t.Kind() {
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32
+	case reflect.Int64:
+		return arrow.PrimitiveTypes.Int64
+	case reflect.Int:
+		if t.Size() == 4 {
+			return arrow.PrimitiveTypes.Int32
+		}
+		return arrow.PrimitiveTypes.Int64
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32
+	case reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64
+	case reflect.Uint:
+		if t.Size() == 4 {
+			return arrow.PrimitiveTypes.Uint32
+		}
+		return arrow.PrimitiveTypes.Uint64
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32
+	default:
+		return arrow.PrimitiveTypes.Float64
+	}
+}
+
+// checkValid panics if valid is not nil and doesn't have length n.
+func checkValid(n int, valid []bool) {
+	if valid != nil && len(valid) != n {
+		panic(fmt.Sprintf("anyarrow: values and valid have different lengths %d and %d", n, len(valid)))
+	}
+}
+
+// validityBitmap creates the validity bitmap from valid and returns the number of nulls,
+// or nil if valid is nil.
+func validityBitmap(valid []bool) (*memory.Buffer, int) {
+	if valid == nil {
+		return nil, 0
+	}
+
+	bits := make([]byte, bitutil.BytesForBits(int64(len(valid))))
+	nulls := 0
+	for i, v := range valid {
+		if v {
+			bitutil.SetBit(bits, i)
+		} else {
+			nulls++
+		}
+	}
+
+	return memory.NewBufferBytes(bits), nulls
+}
+
+// FromStrings creates a string array allocated by mem from vs,
+// where vs[i] is null if valid is not nil and valid[i] is false.
+//
+// valid must be either nil or have the same length as vs, otherwise FromStrings panics.
+func FromStrings[T ~string](mem memory.Allocator, vs []T, valid []bool) arrow.Array {
+	checkValid(len(vs), valid)
+
+	b := array.NewStringBuilder(mem)
+	defer b.Release()
+	a, _ := NewAppender[T](b)
+	_ = a.AppendValues(vs, valid)
+
+	return b.NewArray()
+}
+
+// FromBools creates a boolean array allocated by mem from vs,
+// where vs[i] is null if valid is not nil and valid[i] is false.
+//
+// valid must be either nil or have the same length as vs, otherwise FromBools panics.
+func FromBools(mem memory.Allocator, vs []bool, valid []bool) arrow.Array {
+	checkValid(len(vs), valid)
+
+	b := array.NewBooleanBuilder(mem)
+	defer b.Release()
+	b.AppendValues(vs, valid)
+
+	return b.NewArray()
+}
+
+// minNanoTime and maxNanoTime are the range of time.Time that can be represented by nanosecond timestamps.
+var (
+	minNanoTime = time.Unix(0, math.MinInt64)
+	maxNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// errTimeOverflow is returned by the conversions of FromTimes for times out of the range of the timestamps.
+var errTimeOverflow = errors.New("out of range of nanosecond timestamps")
+
+// FromTimes creates an array of dt allocated by mem from vs,
+// where vs[i] is null if valid is not nil and valid[i] is false.
+//
+// dt must be a timestamp, date32 or date64 type.
+// An [*OverflowError] is returned if a time overflows the timestamp, which can only happen for nanosecond timestamps.
+//
+// valid must be either nil or have the same length as vs, otherwise FromTimes panics.
+func FromTimes(mem memory.Allocator, vs []time.Time, valid []bool, dt arrow.DataType) (arrow.Array, error) {
+	checkValid(len(vs), valid)
+
+	var toValue func(time.Time) (int64, error)
+	switch dt := dt.(type) {
+	case *arrow.TimestampType:
+		toValue = func(t time.Time) (int64, error) {
+			if dt.Unit == arrow.Nanosecond && (t.Before(minNanoTime) || t.After(maxNanoTime)) {
+				return 0, errTimeOverflow
+			}
+			ts, err := arrow.TimestampFromTime(t, dt.Unit)
+			return int64(ts), err
+		}
+	case *arrow.Date32Type:
+		toValue = func(t time.Time) (int64, error) { return int64(arrow.Date32FromTime(t)), nil }
+	case *arrow.Date64Type:
+		toValue = func(t time.Time) (int64, error) { return int64(arrow.Date64FromTime(t)), nil }
+	default:
+		return nil, &UnsupportedTypeError{DataType: dt, GoType: timeType}
+	}
+
+	b := array.NewBuilder(mem, dt)
+	defer b.Release()
+	a, err := NewAppender[int64](b)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Reserve(len(vs))
+	for i, t := range vs {
+		if valid != nil && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		x, err := toValue(t)
+		if err == errTimeOverflow {
+			return nil, &OverflowError{DataType: dt, GoType: timeType, Index: i, Value: t}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %s at index %d to %s: %w", t, i, dt, err)
+		}
+		_ = a.Append(x)
+	}
+
+	return b.NewArray(), nil
+}
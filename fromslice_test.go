@@ -0,0 +1,142 @@
+package anyarrow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestFromSlice(t *testing.T) {
+	type Price float32
+
+	tests := []struct {
+		name     string
+		arr      func([]bool) arrow.Array
+		dt       arrow.DataType
+		expected []int64
+	}{
+		{name: "int64", arr: func(valid []bool) arrow.Array { return anyarrow.FromSlice([]int64{1, 2, 3}, valid) }, dt: arrow.PrimitiveTypes.Int64, expected: []int64{1, 2, 3}},
+		{name: "int", arr: func(valid []bool) arrow.Array { return anyarrow.FromSlice([]int{1, 2, 3}, valid) }, dt: arrow.PrimitiveTypes.Int64, expected: []int64{1, 2, 3}},
+		{name: "uint8", arr: func(valid []bool) arrow.Array { return anyarrow.FromSlice([]uint8{1, 2, 3}, valid) }, dt: arrow.PrimitiveTypes.Uint8, expected: []int64{1, 2, 3}},
+		{name: "named", arr: func(valid []bool) arrow.Array { return anyarrow.FromSlice([]Price{1, 2, 3}, valid) }, dt: arrow.PrimitiveTypes.Float32, expected: []int64{1, 2, 3}},
+		{
+			name:     "duration",
+			arr:      func(valid []bool) arrow.Array { return anyarrow.FromSlice([]time.Duration{1, 2, 3}, valid) },
+			dt:       arrow.FixedWidthTypes.Duration_ns,
+			expected: []int64{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, valid := range [][]bool{nil, {true, false, true}} {
+				arr := tt.arr(valid)
+				defer arr.Release()
+				if !arrow.TypeEqual(arr.DataType(), tt.dt) {
+					t.Fatalf("expected %s, got %s", tt.dt, arr.DataType())
+				}
+
+				i64, err := anyarrow.NewInt64(arr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer i64.Release()
+				for i, expected := range tt.expected {
+					isValid := valid == nil || valid[i]
+					if v, ok := i64.ValueOk(i); ok != isValid || (ok && v != expected) {
+						t.Errorf("at %d: expected (%d, %t), got (%d, %t)", i, expected, isValid, v, ok)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFromSliceShared(t *testing.T) {
+	vs := []int32{1, 2, 3}
+	arr := anyarrow.FromSlice(vs, nil)
+	defer arr.Release()
+
+	vs[1] = 20
+	if v := arr.(*array.Int32).Value(1); v != 20 {
+		t.Errorf("expected the array to share memory with the slice, got %d", v)
+	}
+
+	sliced := array.NewSlice(arr, 1, 3)
+	defer sliced.Release()
+	if v := sliced.(*array.Int32).Value(0); v != 20 {
+		t.Errorf("expected 20 in sliced array, got %d", v)
+	}
+}
+
+func TestFromSlicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for mismatched lengths")
+		}
+	}()
+
+	anyarrow.FromSlice([]int64{1, 2}, []bool{true})
+}
+
+func TestFromBuilders(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	valid := []bool{true, false, true}
+
+	strs := anyarrow.FromStrings(mem, []string{"a", "b", "c"}, valid)
+	defer strs.Release()
+	if s := strs.String(); s != `["a" (null) "c"]` {
+		t.Errorf("unexpected strings %s", s)
+	}
+
+	bools := anyarrow.FromBools(mem, []bool{true, true, false}, valid)
+	defer bools.Release()
+	if s := bools.String(); s != "[true (null) false]" {
+		t.Errorf("unexpected bools %s", s)
+	}
+
+	times := []time.Time{time.UnixMilli(1000).UTC(), {}, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	for _, dt := range []arrow.DataType{arrow.FixedWidthTypes.Timestamp_ms, arrow.FixedWidthTypes.Date32, arrow.FixedWidthTypes.Date64} {
+		arr, err := anyarrow.FromTimes(mem, times, valid, dt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer arr.Release()
+
+		toTime, err := anyarrow.TimeFunc(dt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i64, err := anyarrow.NewInt64(arr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer i64.Release()
+		for _, i := range []int{0, 2} {
+			expected := times[i]
+			if dt.ID() != arrow.TIMESTAMP {
+				expected = expected.Truncate(24 * time.Hour)
+			}
+			if v := toTime(i64.Value(i)); !v.Equal(expected) {
+				t.Errorf("%s at %d: expected %s, got %s", dt, i, expected, v)
+			}
+		}
+		if i64.IsValid(1) {
+			t.Errorf("%s: expected null at 1", dt)
+		}
+	}
+
+	if _, err := anyarrow.FromTimes(mem, times, nil, arrow.FixedWidthTypes.Timestamp_ns); err == nil {
+		t.Error("expected error for zero time in nanosecond timestamp")
+	}
+	if _, err := anyarrow.FromTimes(mem, times, nil, arrow.PrimitiveTypes.Int64); err == nil {
+		t.Error("expected error for int64 type")
+	}
+}
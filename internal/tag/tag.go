@@ -0,0 +1,101 @@
+// Package tag parses the `arrow:"name,options"` struct tags shared by the reflection based codecs and the code generator.
+package tag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+// Key is the key of the struct tag that maps struct fields to arrow columns.
+const Key = "arrow"
+
+// Parse splits the value of a struct tag into the column name and the comma separated options following it.
+func Parse(tag string) (name string, options []string) {
+	name, rest, _ := strings.Cut(tag, ",")
+	if rest != "" {
+		options = strings.Split(rest, ",")
+	}
+
+	return name, options
+}
+
+// Options are the options in the struct tag that choose the arrow type of a field when encoding.
+type Options struct {
+	// Dictionary encodes the field as a dictionary with int32 indices.
+	Dictionary bool
+	// Unit is the unit of timestamp and duration fields.
+	Unit arrow.TimeUnit
+	// TimeZone is the time zone of timestamp fields.
+	TimeZone string
+	// Date is the date type of time.Time fields, or nil for timestamps.
+	Date arrow.DataType
+	// Decimal is set if the field is encoded as a decimal with Precision and Scale.
+	Decimal          bool
+	Precision, Scale int32
+}
+
+// ParseOptions parses the options of a struct tag, which are
+//   - dict: encodes the field as a dictionary.
+//   - unit=s|ms|us|ns: the unit of timestamp and duration fields, nanosecond by default.
+//   - tz=zone: the time zone of timestamp fields, UTC by default.
+//   - date32, date64: encodes time.Time fields as dates instead of timestamps.
+//   - scale=n, precision=n: encodes numeric fields as decimal128, the precision is 38 by default.
+//     The scale must be between 0 and the precision.
+func ParseOptions(options []string) (Options, error) {
+	o := Options{Unit: arrow.Nanosecond, TimeZone: "UTC", Precision: 38}
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		var err error
+		switch key {
+		case "dict":
+			o.Dictionary = true
+		case "unit":
+			o.Unit, err = parseTimeUnit(value)
+		case "tz":
+			o.TimeZone = value
+		case "date32":
+			o.Date = arrow.FixedWidthTypes.Date32
+		case "date64":
+			o.Date = arrow.FixedWidthTypes.Date64
+		case "scale":
+			o.Decimal = true
+			o.Scale, err = parseInt32(value)
+		case "precision":
+			o.Decimal = true
+			o.Precision, err = parseInt32(value)
+		default:
+			err = fmt.Errorf("unknown option %q", option)
+		}
+		if err != nil {
+			return o, err
+		}
+	}
+
+	if o.Decimal && (o.Precision < 1 || o.Precision > 38 || o.Scale < 0 || o.Scale > o.Precision) {
+		return o, fmt.Errorf("invalid decimal precision %d and scale %d", o.Precision, o.Scale)
+	}
+
+	return o, nil
+}
+
+func parseTimeUnit(s string) (arrow.TimeUnit, error) {
+	for _, unit := range []arrow.TimeUnit{arrow.Second, arrow.Millisecond, arrow.Microsecond, arrow.Nanosecond} {
+		if unit.String() == s {
+			return unit, nil
+		}
+	}
+
+	return arrow.Nanosecond, fmt.Errorf("unknown time unit %q", s)
+}
+
+func parseInt32(s string) (int32, error) {
+	v, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+
+	return int32(v), nil
+}
@@ -0,0 +1,39 @@
+// Package tick is an example of the codecs generated by cmd/codecgen,
+// and tests they behave the same as [anyarrow.Marshal] and [anyarrow.Unmarshal].
+package tick
+
+import (
+	"time"
+
+	"github.com/fardream/anyarrow"
+)
+
+//go:generate go run ../../cmd/codecgen -type Tick
+
+// Price is a named type read by the generic accessors.
+type Price float64
+
+// Meta is embedded and its fields are promoted.
+type Meta struct {
+	Source string `arrow:"source,dict"`
+}
+
+// Tick is a trade or quote of a symbol.
+type Tick struct {
+	Meta
+	Symbol   string                    `arrow:"symbol,dict"`
+	Time     time.Time                 `arrow:"time,unit=ms"`
+	Date     time.Time                 `arrow:"date,date32"`
+	Price    Price                     `arrow:"price"`
+	Notional float64                   `arrow:"notional,precision=18,scale=2"`
+	Size     *int32                    `arrow:"size"`
+	Count    int                       `arrow:"count"`
+	Flags    uint16                    `arrow:"flags"`
+	Venue    anyarrow.Nullable[string] `arrow:"venue"`
+	Side     anyarrow.Nullable[int8]   `arrow:"side"`
+	Trade    bool                      `arrow:"trade"`
+	Latency  time.Duration             `arrow:"latency,unit=us"`
+	Raw      []byte                    `arrow:"raw"`
+	Type     string
+	Ignored  string `arrow:"-"`
+}
@@ -0,0 +1,438 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package tick
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+	"github.com/fardream/anyarrow"
+)
+
+var schemaOfTick = arrow.NewSchema([]arrow.Field{
+	{Name: "source", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}},
+	{Name: "symbol", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}},
+	{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+	{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+	{Name: "price", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "notional", Type: &arrow.Decimal128Type{Precision: 18, Scale: 2}},
+	{Name: "size", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	{Name: "count", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "flags", Type: arrow.PrimitiveTypes.Uint16},
+	{Name: "venue", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "side", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+	{Name: "trade", Type: arrow.FixedWidthTypes.Boolean},
+	{Name: "latency", Type: &arrow.DurationType{Unit: arrow.Microsecond}},
+	{Name: "raw", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "Type", Type: arrow.BinaryTypes.String},
+}, nil)
+
+// MarshalTick encodes rows into an [arrow.Record] allocated by mem, like [anyarrow.Marshal] but without reflection.
+//
+// The caller must release the returned record.
+func MarshalTick(mem memory.Allocator, rows []Tick) (arrow.Record, error) {
+	b := array.NewRecordBuilder(mem, schemaOfTick)
+	defer b.Release()
+	b.Reserve(len(rows))
+
+	metaSourceBuilder := b.Field(0).(*array.BinaryDictionaryBuilder)
+	for i := range rows {
+		if err := metaSourceBuilder.AppendString(rows[i].Meta.Source); err != nil {
+			return nil, fmt.Errorf("cannot encode row %d field tick.Tick.Meta.Source: %w", i, err)
+		}
+	}
+
+	symbolBuilder := b.Field(1).(*array.BinaryDictionaryBuilder)
+	for i := range rows {
+		if err := symbolBuilder.AppendString(rows[i].Symbol); err != nil {
+			return nil, fmt.Errorf("cannot encode row %d field tick.Tick.Symbol: %w", i, err)
+		}
+	}
+
+	timeBuilder := b.Field(2).(*array.TimestampBuilder)
+	for i := range rows {
+		ts, err := arrow.TimestampFromTime(rows[i].Time, arrow.Millisecond)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode row %d field tick.Tick.Time: %w", i, err)
+		}
+		timeBuilder.Append(ts)
+	}
+
+	dateBuilder := b.Field(3).(*array.Date32Builder)
+	for i := range rows {
+		dateBuilder.Append(arrow.Date32FromTime(rows[i].Date))
+	}
+
+	priceBuilder := b.Field(4).(*array.Float64Builder)
+	for i := range rows {
+		priceBuilder.Append(float64(rows[i].Price))
+	}
+
+	notionalBuilder := b.Field(5).(*array.Decimal128Builder)
+	for i := range rows {
+		n, err := decimal128.FromFloat64(rows[i].Notional, 18, 2)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode row %d field tick.Tick.Notional: %w", i, &anyarrow.OverflowError{DataType: notionalBuilder.Type(), GoType: reflect.TypeOf(rows[i].Notional), Index: i, Value: rows[i].Notional})
+		}
+		notionalBuilder.Append(n)
+	}
+
+	sizeBuilder := b.Field(6).(*array.Int32Builder)
+	for i := range rows {
+		if rows[i].Size == nil {
+			sizeBuilder.AppendNull()
+		} else {
+			sizeBuilder.Append(*rows[i].Size)
+		}
+	}
+
+	countBuilder := b.Field(7).(*array.Int64Builder)
+	for i := range rows {
+		countBuilder.Append(int64(rows[i].Count))
+	}
+
+	flagsBuilder := b.Field(8).(*array.Uint16Builder)
+	for i := range rows {
+		flagsBuilder.Append(rows[i].Flags)
+	}
+
+	venueBuilder := b.Field(9).(*array.StringBuilder)
+	for i := range rows {
+		if !rows[i].Venue.Valid {
+			venueBuilder.AppendNull()
+		} else {
+			venueBuilder.Append(rows[i].Venue.Value)
+		}
+	}
+
+	sideBuilder := b.Field(10).(*array.Int8Builder)
+	for i := range rows {
+		if !rows[i].Side.Valid {
+			sideBuilder.AppendNull()
+		} else {
+			sideBuilder.Append(rows[i].Side.Value)
+		}
+	}
+
+	tradeBuilder := b.Field(11).(*array.BooleanBuilder)
+	for i := range rows {
+		tradeBuilder.Append(rows[i].Trade)
+	}
+
+	latencyBuilder := b.Field(12).(*array.DurationBuilder)
+	for i := range rows {
+		latencyBuilder.Append(arrow.Duration(rows[i].Latency / time.Microsecond))
+	}
+
+	rawBuilder := b.Field(13).(*array.BinaryBuilder)
+	for i := range rows {
+		if rows[i].Raw == nil {
+			rawBuilder.AppendNull()
+		} else {
+			rawBuilder.Append(rows[i].Raw)
+		}
+	}
+
+	type1Builder := b.Field(14).(*array.StringBuilder)
+	for i := range rows {
+		type1Builder.Append(rows[i].Type)
+	}
+
+	return b.NewRecord(), nil
+}
+
+// UnmarshalTick decodes the rows of rec into dst, which is resized to the number of rows of rec,
+// like [anyarrow.Unmarshal] but without reflection.
+// The decoded values don't share memory with rec, which can be released right after.
+func UnmarshalTick(rec arrow.Record, dst *[]Tick) error {
+	d, err := NewTickDecoder(rec)
+	if err != nil {
+		return err
+	}
+	defer d.Release()
+
+	n := int(rec.NumRows())
+	*dst = slices.Grow((*dst)[:0], n)[:n]
+	for i := range *dst {
+		(*dst)[i] = Tick{}
+		d.DecodeRow(i, &(*dst)[i])
+	}
+
+	return nil
+}
+
+// TickDecoder decodes the rows of an [arrow.Record] into [Tick], like [anyarrow.Decoder] but without reflection.
+type TickDecoder struct {
+	metaSource        *anyarrow.String
+	symbol            *anyarrow.String
+	time              *anyarrow.Int64
+	timeToTime        func(int64) time.Time
+	date              *anyarrow.Int64
+	dateToTime        func(int64) time.Time
+	price             *anyarrow.Float64Of[Price]
+	notional          *anyarrow.Float64
+	size              *anyarrow.Int32
+	count             *anyarrow.Int64
+	flags             *anyarrow.Uint16
+	venue             *anyarrow.String
+	side              *anyarrow.Int8
+	trade             *anyarrow.Float64
+	latency           *anyarrow.Int64
+	latencyToDuration func(int64) time.Duration
+	raw               *anyarrow.String
+	type1             *anyarrow.String
+}
+
+// NewTickDecoder creates a [TickDecoder] for the rows of rec.
+//
+// The returned error lists every column that is missing or cannot be decoded into its field.
+func NewTickDecoder(rec arrow.Record) (*TickDecoder, error) {
+	d := &TickDecoder{}
+	schema := rec.Schema()
+	var errs []error
+	var err error
+
+	if k := schema.FieldIndices("source"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Meta.Source", "source"))
+	} else if d.metaSource, err = anyarrow.NewString(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Meta.Source: %w", "source", err))
+	}
+
+	if k := schema.FieldIndices("symbol"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Symbol", "symbol"))
+	} else if d.symbol, err = anyarrow.NewString(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Symbol: %w", "symbol", err))
+	}
+
+	if k := schema.FieldIndices("time"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Time", "time"))
+	} else if d.timeToTime, err = anyarrow.TimeFunc(schema.Field(k[0]).Type); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Time: %w", "time", err))
+	} else if d.time, err = anyarrow.NewInt64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Time: %w", "time", err))
+	}
+
+	if k := schema.FieldIndices("date"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Date", "date"))
+	} else if d.dateToTime, err = anyarrow.TimeFunc(schema.Field(k[0]).Type); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Date: %w", "date", err))
+	} else if d.date, err = anyarrow.NewInt64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Date: %w", "date", err))
+	}
+
+	if k := schema.FieldIndices("price"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Price", "price"))
+	} else if d.price, err = anyarrow.NewFloat64Of[Price](rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Price: %w", "price", err))
+	}
+
+	if k := schema.FieldIndices("notional"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Notional", "notional"))
+	} else if d.notional, err = anyarrow.NewFloat64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Notional: %w", "notional", err))
+	}
+
+	if k := schema.FieldIndices("size"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Size", "size"))
+	} else if d.size, err = anyarrow.NewInt32(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Size: %w", "size", err))
+	}
+
+	if k := schema.FieldIndices("count"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Count", "count"))
+	} else if d.count, err = anyarrow.NewInt64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Count: %w", "count", err))
+	}
+
+	if k := schema.FieldIndices("flags"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Flags", "flags"))
+	} else if d.flags, err = anyarrow.NewUint16(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Flags: %w", "flags", err))
+	}
+
+	if k := schema.FieldIndices("venue"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Venue", "venue"))
+	} else if d.venue, err = anyarrow.NewString(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Venue: %w", "venue", err))
+	}
+
+	if k := schema.FieldIndices("side"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Side", "side"))
+	} else if d.side, err = anyarrow.NewInt8(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Side: %w", "side", err))
+	}
+
+	if k := schema.FieldIndices("trade"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Trade", "trade"))
+	} else if d.trade, err = anyarrow.NewFloat64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Trade: %w", "trade", err))
+	}
+
+	if k := schema.FieldIndices("latency"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Latency", "latency"))
+	} else if d.latency, err = anyarrow.NewInt64(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Latency: %w", "latency", err))
+	} else if d.latencyToDuration, err = anyarrow.DurationFunc(schema.Field(k[0]).Type); err != nil {
+		// like anyarrow.Unmarshal, columns other than durations are read as nanoseconds.
+		d.latencyToDuration = func(x int64) time.Duration { return time.Duration(x) }
+	}
+
+	if k := schema.FieldIndices("raw"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Raw", "raw"))
+	} else if d.raw, err = anyarrow.NewString(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Raw: %w", "raw", err))
+	}
+
+	if k := schema.FieldIndices("Type"); len(k) == 0 {
+		errs = append(errs, fmt.Errorf("missing column %q for field tick.Tick.Type", "Type"))
+	} else if d.type1, err = anyarrow.NewString(rec.Column(k[0])); err != nil {
+		errs = append(errs, fmt.Errorf("cannot decode column %q into field tick.Tick.Type: %w", "Type", err))
+	}
+
+	if len(errs) > 0 {
+		d.Release()
+		return nil, errors.Join(errs...)
+	}
+
+	return d, nil
+}
+
+// DecodeRow decodes row i into v.
+// Strings and []byte are copied, so v remains valid after the record is released.
+func (d *TickDecoder) DecodeRow(i int, v *Tick) {
+	if x, ok := d.metaSource.ValueOk(i); ok {
+		v.Meta.Source = strings.Clone(x)
+	} else {
+		v.Meta.Source = ""
+	}
+	if x, ok := d.symbol.ValueOk(i); ok {
+		v.Symbol = strings.Clone(x)
+	} else {
+		v.Symbol = ""
+	}
+	if x, ok := d.time.ValueOk(i); ok {
+		v.Time = d.timeToTime(x)
+	} else {
+		v.Time = time.Time{}
+	}
+	if x, ok := d.date.ValueOk(i); ok {
+		v.Date = d.dateToTime(x)
+	} else {
+		v.Date = time.Time{}
+	}
+	if x, ok := d.price.ValueOk(i); ok {
+		v.Price = x
+	} else {
+		v.Price = 0
+	}
+	if x, ok := d.notional.ValueOk(i); ok {
+		v.Notional = x
+	} else {
+		v.Notional = 0
+	}
+	if x, ok := d.size.ValueOk(i); ok {
+		y := x
+		v.Size = &y
+	} else {
+		v.Size = nil
+	}
+	if x, ok := d.count.ValueOk(i); ok {
+		v.Count = int(x)
+	} else {
+		v.Count = 0
+	}
+	if x, ok := d.flags.ValueOk(i); ok {
+		v.Flags = x
+	} else {
+		v.Flags = 0
+	}
+	if x, ok := d.venue.ValueOk(i); ok {
+		v.Venue.Value = strings.Clone(x)
+		v.Venue.Valid = true
+	} else {
+		v.Venue = anyarrow.Nullable[string]{}
+	}
+	if x, ok := d.side.ValueOk(i); ok {
+		v.Side.Value = x
+		v.Side.Valid = true
+	} else {
+		v.Side = anyarrow.Nullable[int8]{}
+	}
+	if x, ok := d.trade.ValueOk(i); ok {
+		v.Trade = x != 0
+	} else {
+		v.Trade = false
+	}
+	if x, ok := d.latency.ValueOk(i); ok {
+		v.Latency = d.latencyToDuration(x)
+	} else {
+		v.Latency = 0
+	}
+	if x, ok := d.raw.ValueOk(i); ok {
+		v.Raw = append(v.Raw[:0], x...)
+	} else {
+		v.Raw = nil
+	}
+	if x, ok := d.type1.ValueOk(i); ok {
+		v.Type = strings.Clone(x)
+	} else {
+		v.Type = ""
+	}
+}
+
+// Release releases the accessors held by the decoder.
+func (d *TickDecoder) Release() {
+	if d.metaSource != nil {
+		d.metaSource.Release()
+	}
+	if d.symbol != nil {
+		d.symbol.Release()
+	}
+	if d.time != nil {
+		d.time.Release()
+	}
+	if d.date != nil {
+		d.date.Release()
+	}
+	if d.price != nil {
+		d.price.Release()
+	}
+	if d.notional != nil {
+		d.notional.Release()
+	}
+	if d.size != nil {
+		d.size.Release()
+	}
+	if d.count != nil {
+		d.count.Release()
+	}
+	if d.flags != nil {
+		d.flags.Release()
+	}
+	if d.venue != nil {
+		d.venue.Release()
+	}
+	if d.side != nil {
+		d.side.Release()
+	}
+	if d.trade != nil {
+		d.trade.Release()
+	}
+	if d.latency != nil {
+		d.latency.Release()
+	}
+	if d.raw != nil {
+		d.raw.Release()
+	}
+	if d.type1 != nil {
+		d.type1.Release()
+	}
+}
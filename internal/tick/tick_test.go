@@ -0,0 +1,292 @@
+package tick_test
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+	"github.com/fardream/anyarrow/internal/tick"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func newTicks(n int) []tick.Tick {
+	ticks := make([]tick.Tick, n)
+	for i := range ticks {
+		size := int32(i * 10)
+		ticks[i] = tick.Tick{
+			Meta:     tick.Meta{Source: []string{"a", "b"}[i%2]},
+			Symbol:   []string{"X", "Y", "Z"}[i%3],
+			Time:     time.UnixMilli(int64(i) * 1000).UTC(),
+			Date:     time.Date(2024, 1, 1+i%28, 0, 0, 0, 0, time.UTC),
+			Price:    tick.Price(i) + 0.5,
+			Notional: float64(i) * 100.25,
+			Count:    i,
+			Flags:    uint16(i),
+			Trade:    i%2 == 0,
+			Latency:  time.Duration(i) * time.Microsecond,
+			Type:     "t",
+		}
+		if i%3 != 0 {
+			ticks[i].Size = &size
+			ticks[i].Venue = anyarrow.Nullable[string]{Value: "V", Valid: true}
+			ticks[i].Raw = []byte{byte(i)}
+		}
+		if i%4 != 0 {
+			ticks[i].Side = anyarrow.Nullable[int8]{Value: int8(i % 2), Valid: true}
+		}
+	}
+
+	return ticks
+}
+
+func TestCodec(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ticks := newTicks(10)
+	ticks[1].Ignored = "ignored"
+
+	rec, err := tick.MarshalTick(mem, ticks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	expected, err := anyarrow.Marshal(mem, ticks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer expected.Release()
+	if !rec.Schema().Equal(expected.Schema()) {
+		t.Fatalf("expected schema %s, got %s", expected.Schema(), rec.Schema())
+	}
+	if !array.RecordEqual(rec, expected) {
+		t.Fatalf("expected record %v, got %v", expected, rec)
+	}
+
+	var decoded, expectedDecoded []tick.Tick
+	if err := tick.UnmarshalTick(rec, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := anyarrow.Unmarshal(rec, &expectedDecoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, expectedDecoded) {
+		t.Errorf("expected %+v, got %+v", expectedDecoded, decoded)
+	}
+
+	ticks[1].Ignored = ""
+	if !reflect.DeepEqual(decoded, ticks) {
+		t.Errorf("expected %+v, got %+v", ticks, decoded)
+	}
+}
+
+// scribbleAllocator overwrites the memory it frees, like a pool reusing it.
+type scribbleAllocator struct {
+	memory.Allocator
+}
+
+func (a scribbleAllocator) Free(b []byte) {
+	for i := range b {
+		b[i] = 'X'
+	}
+	a.Allocator.Free(b)
+}
+
+func TestUnmarshalAfterRelease(t *testing.T) {
+	ticks := newTicks(10)
+
+	rec, err := tick.MarshalTick(scribbleAllocator{memory.NewGoAllocator()}, ticks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []tick.Tick
+	err = tick.UnmarshalTick(rec, &decoded)
+	rec.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, ticks) {
+		t.Errorf("expected %+v, got %+v", ticks, decoded)
+	}
+}
+
+// replaceColumn returns rec with the column name replaced by arr.
+func replaceColumn(rec arrow.Record, name string, arr arrow.Array) arrow.Record {
+	fields := slices.Clone(rec.Schema().Fields())
+	columns := slices.Clone(rec.Columns())
+	k := rec.Schema().FieldIndices(name)[0]
+	fields[k] = arrow.Field{Name: name, Type: arr.DataType(), Nullable: true}
+	columns[k] = arr
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), columns, rec.NumRows())
+}
+
+func TestDecodeNanoseconds(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ticks := newTicks(3)
+	rec, err := tick.MarshalTick(mem, ticks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	for _, tk := range ticks {
+		ib.Append(int64(tk.Latency))
+	}
+	latency := ib.NewArray()
+	defer latency.Release()
+	nanoseconds := replaceColumn(rec, "latency", latency)
+	defer nanoseconds.Release()
+
+	var decoded, expected []tick.Tick
+	if err := tick.UnmarshalTick(nanoseconds, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := anyarrow.Unmarshal(nanoseconds, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, expected) || !reflect.DeepEqual(decoded, ticks) {
+		t.Errorf("expected %+v, got %+v", ticks, decoded)
+	}
+}
+
+func TestDecodeNullColumns(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec, err := tick.MarshalTick(mem, newTicks(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	nulls := array.NewNull(int(rec.NumRows()))
+	defer nulls.Release()
+	for _, name := range []string{"time", "latency", "size", "venue", "symbol"} {
+		replaced := replaceColumn(rec, name, nulls)
+		defer replaced.Release()
+		rec = replaced
+	}
+
+	var decoded, expected []tick.Tick
+	if err := tick.UnmarshalTick(rec, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := anyarrow.Unmarshal(rec, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %+v, got %+v", expected, decoded)
+	}
+	for _, tk := range decoded {
+		if !tk.Time.IsZero() || tk.Latency != 0 || tk.Size != nil || tk.Venue.Valid || tk.Symbol != "" {
+			t.Errorf("expected null columns to be decoded as zero values, got %+v", tk)
+		}
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec, err := tick.MarshalTick(mem, newTicks(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	// drop the column "symbol" and make "time" a string column.
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.Append("t")
+	str := sb.NewArray()
+	defer str.Release()
+
+	var fields []arrow.Field
+	var columns []arrow.Array
+	for i, f := range rec.Schema().Fields() {
+		switch f.Name {
+		case "symbol":
+			continue
+		case "time":
+			fields = append(fields, arrow.Field{Name: "time", Type: arrow.BinaryTypes.String})
+			columns = append(columns, str)
+		default:
+			fields = append(fields, f)
+			columns = append(columns, rec.Column(i))
+		}
+	}
+	invalid := array.NewRecord(arrow.NewSchema(fields, nil), columns, 1)
+	defer invalid.Release()
+
+	var ticks []tick.Tick
+	err = tick.UnmarshalTick(invalid, &ticks)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, s := range []string{`missing column "symbol" for field tick.Tick.Symbol`, `cannot decode column "time" into field tick.Tick.Time`} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected error %q to contain %q", err, s)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	mem := memory.NewGoAllocator()
+	rec, err := tick.MarshalTick(mem, newTicks(4096))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer rec.Release()
+
+	var ticks []tick.Tick
+	b.Run("generated", func(b *testing.B) {
+		for range b.N {
+			if err := tick.UnmarshalTick(rec, &ticks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		for range b.N {
+			if err := anyarrow.Unmarshal(rec, &ticks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	mem := memory.NewGoAllocator()
+	ticks := newTicks(4096)
+
+	b.Run("generated", func(b *testing.B) {
+		for range b.N {
+			rec, err := tick.MarshalTick(mem, ticks)
+			if err != nil {
+				b.Fatal(err)
+			}
+			rec.Release()
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		for range b.N {
+			rec, err := anyarrow.Marshal(mem, ticks)
+			if err != nil {
+				b.Fatal(err)
+			}
+			rec.Release()
+		}
+	})
+}
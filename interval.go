@@ -0,0 +1,201 @@
+package anyarrow
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/bitutil"
+)
+
+// IntervalValue is a calendar interval of months, days and nanoseconds,
+// which holds the values of all arrow interval types.
+//
+// The components are independent, since months have different numbers of days,
+// and days can have different numbers of nanoseconds across daylight saving time changes.
+type IntervalValue struct {
+	Months      int32
+	Days        int32
+	Nanoseconds int64
+}
+
+// AddTo returns t shifted forward by the interval,
+// adding the months and days by [time.Time.AddDate] before the nanoseconds.
+func (v IntervalValue) AddTo(t time.Time) time.Time {
+	return t.AddDate(0, int(v.Months), int(v.Days)).Add(time.Duration(v.Nanoseconds))
+}
+
+// SubtractFrom returns t shifted backward by the interval, which is the reverse of AddTo.
+func (v IntervalValue) SubtractFrom(t time.Time) time.Time {
+	return t.Add(-time.Duration(v.Nanoseconds)).AddDate(0, -int(v.Months), -int(v.Days))
+}
+
+// Interval provides access to the elements of month, day time and month day nano interval arrays as [IntervalValue].
+// Dictionaries of intervals are also supported.
+type Interval struct {
+	arrowArray
+
+	// id is the type of the values, which is one of the interval types or arrow.NULL.
+	id arrow.Type
+	// values of the array, or values of the dictionary if the array is a dictionary,
+	// only the one of the interval type is set.
+	months        []arrow.MonthInterval
+	dayTimes      []arrow.DayTimeInterval
+	monthDayNanos []arrow.MonthDayNanoInterval
+	// indices of the dictionary, or [kindUnknown] if the array is not a dictionary.
+	indices buffer
+	// valid and validOffset are the validity bitmap of the dictionary values, only set if they contain nulls.
+	valid       []byte
+	validOffset int
+	// nulls is the number of null elements, only set for dictionaries with null values.
+	nulls int
+
+	refCount int64
+}
+
+var _ arrow.Array = (*Interval)(nil)
+
+var intervalValueType = reflect.TypeFor[IntervalValue]()
+
+// NewInterval wraps the provided [arrow.Array].
+// The [arrow.Array] is retained, and released when the accessor is released.
+//
+// [array.Null], and dictionaries of null values, are accepted with every element null and read as the zero value.
+//
+// An [*UnsupportedTypeError] or an [*UnsupportedDictionaryError] is returned if the array is not an interval array
+// or a dictionary of intervals.
+func NewInterval(a arrow.Array) (*Interval, error) {
+	r := &Interval{arrowArray: arrowArray{Array: a}, refCount: 1}
+
+	v, ok := a.(*array.Dictionary)
+	if !ok {
+		if !r.setValues(a) {
+			return nil, &UnsupportedTypeError{DataType: a.DataType(), GoType: intervalValueType}
+		}
+		a.Retain()
+
+		return r, nil
+	}
+
+	dt, ok := v.DataType().(*arrow.DictionaryType)
+	if !ok {
+		return nil, &DictionaryMismatchError{DataType: v.DataType(), GoType: intervalValueType}
+	}
+	indices, ok := indexBuffer(v.Indices())
+	if !ok || !r.setValues(v.Dictionary()) {
+		return nil, &UnsupportedDictionaryError{DataType: dt, GoType: intervalValueType}
+	}
+	r.indices = indices
+	if values := v.Dictionary(); values.NullN() > 0 {
+		r.valid, r.validOffset = values.NullBitmapBytes(), values.Data().Offset()
+		for i := range a.Len() {
+			if r.IsNull(i) {
+				r.nulls++
+			}
+		}
+	}
+	a.Retain()
+
+	return r, nil
+}
+
+// setValues sets the values to the ones of arr, and reports whether arr is an interval or a null array.
+func (a *Interval) setValues(arr arrow.Array) bool {
+	switch v := arr.(type) {
+	case *array.MonthInterval:
+		a.months = v.MonthIntervalValues()
+	case *array.DayTimeInterval:
+		a.dayTimes = v.DayTimeIntervalValues()
+	case *array.MonthDayNanoInterval:
+		a.monthDayNanos = v.MonthDayNanoIntervalValues()
+	case *array.Null:
+	default:
+		return false
+	}
+	a.id = arr.DataType().ID()
+
+	return true
+}
+
+// Value retrieves the element at index i.
+func (a *Interval) Value(i int) IntervalValue {
+	if a.indices.kind != kindUnknown {
+		i = a.indices.index(i)
+	}
+
+	switch a.id {
+	case arrow.INTERVAL_MONTHS:
+		return IntervalValue{Months: int32(a.months[i])}
+	case arrow.INTERVAL_DAY_TIME:
+		v := a.dayTimes[i]
+		return IntervalValue{Days: v.Days, Nanoseconds: int64(v.Milliseconds) * int64(time.Millisecond)}
+	case arrow.NULL:
+		return IntervalValue{}
+	default:
+		v := a.monthDayNanos[i]
+		return IntervalValue{Months: v.Months, Days: v.Days, Nanoseconds: v.Nanoseconds}
+	}
+}
+
+// ValueOk retrieves the element at index i, and whether it is valid.
+func (a *Interval) ValueOk(i int) (IntervalValue, bool) {
+	if !a.IsValid(i) {
+		return IntervalValue{}, false
+	}
+
+	return a.Value(i), true
+}
+
+// IsValid indicates if the element at index i is not null.
+//
+// For dictionaries, the element is null if either its index or its dictionary value is null.
+// The elements of [array.Null] are always null.
+func (a *Interval) IsValid(i int) bool {
+	if !a.Array.IsValid(i) {
+		return false
+	}
+	if a.valid != nil {
+		return bitutil.BitIsSet(a.valid, a.validOffset+a.indices.index(i))
+	}
+
+	return a.id != arrow.NULL
+}
+
+// IsNull indicates if the element at index i is null, see IsValid.
+func (a *Interval) IsNull(i int) bool {
+	return !a.IsValid(i)
+}
+
+// NullN returns the number of null elements, see IsValid.
+func (a *Interval) NullN() int {
+	if a.id == arrow.NULL {
+		return a.Len()
+	}
+	if a.valid != nil {
+		return a.nulls
+	}
+
+	return a.Array.NullN()
+}
+
+// IsDictionary indicates if the underlying [arrow.Array] is an [array.Dictionary].
+func (a *Interval) IsDictionary() bool {
+	return a.indices.kind != kindUnknown
+}
+
+// Retain increases the reference count of the accessor by 1.
+func (a *Interval) Retain() {
+	atomic.AddInt64(&a.refCount, 1)
+}
+
+// Release decreases the reference count of the accessor by 1.
+// When the reference count reaches zero, the underlying [arrow.Array] is released,
+// and the accessor must not be used anymore.
+func (a *Interval) Release() {
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.Array.Release()
+		*a = Interval{}
+	}
+}
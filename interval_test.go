@@ -0,0 +1,102 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestInterval(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tests := []struct {
+		dt       arrow.DataType
+		json     string
+		expected []anyarrow.IntervalValue
+	}{
+		{arrow.FixedWidthTypes.MonthInterval, `[{"months": 3}, null]`, []anyarrow.IntervalValue{{Months: 3}, {}}},
+		{
+			arrow.FixedWidthTypes.DayTimeInterval,
+			`[{"days": 2, "milliseconds": 1500}]`,
+			[]anyarrow.IntervalValue{{Days: 2, Nanoseconds: int64(1500 * time.Millisecond)}},
+		},
+		{
+			arrow.FixedWidthTypes.MonthDayNanoInterval,
+			`[null, {"months": -1, "days": 1, "nanoseconds": 7}]`,
+			[]anyarrow.IntervalValue{{}, {Months: -1, Days: 1, Nanoseconds: 7}},
+		},
+	}
+
+	for _, test := range tests {
+		arr, _, err := array.FromJSON(mem, test.dt, strings.NewReader(test.json))
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		a, err := anyarrow.NewInterval(arr)
+		arr.Release()
+		if err != nil {
+			t.Fatalf("%s: %v", test.dt, err)
+		}
+		for i, expected := range test.expected {
+			v, ok := a.ValueOk(i)
+			if v != expected || ok == arr.IsNull(i) {
+				t.Errorf("%s at %d: expected %+v, got %+v and %t", test.dt, i, expected, v, ok)
+			}
+		}
+		a.Release()
+	}
+}
+
+func TestIntervalDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	values, _, err := array.FromJSON(mem, arrow.FixedWidthTypes.MonthInterval, strings.NewReader(`[{"months": 1}, null, {"months": 12}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer values.Release()
+	dict := newDictionary(t, mem, []int16{2, 1, 0, 2}, []bool{true, true, false, true}, values)
+	defer dict.Release()
+
+	a, err := anyarrow.NewInterval(dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Release()
+
+	if !a.IsDictionary() || a.NullN() != 2 || a.IsValid(1) || a.IsValid(2) {
+		t.Errorf("expected elements 1 and 2 of the dictionary to be null, got %d nulls", a.NullN())
+	}
+	if v := a.Value(3); v != (anyarrow.IntervalValue{Months: 12}) {
+		t.Errorf("expected 12 months, got %+v", v)
+	}
+
+	ints := anyarrow.FromSlice([]int32{1}, nil)
+	defer ints.Release()
+	var typeErr *anyarrow.UnsupportedTypeError
+	if _, err := anyarrow.NewInterval(ints); !errors.As(err, &typeErr) {
+		t.Errorf("expected UnsupportedTypeError, got %v", err)
+	}
+}
+
+func TestIntervalValueAddTo(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	v := anyarrow.IntervalValue{Months: 1, Days: 1, Nanoseconds: int64(time.Hour)}
+
+	if got, expected := v.AddTo(start), time.Date(2024, time.March, 3, 13, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	end := time.Date(2024, time.March, 3, 13, 0, 0, 0, time.UTC)
+	if got, expected := v.SubtractFrom(end), time.Date(2024, time.February, 2, 12, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
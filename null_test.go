@@ -0,0 +1,195 @@
+package anyarrow_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestDictionaryNulls(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	// dictionary values are "a", null, "c"
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	values := sb.NewArray()
+	defer values.Release()
+
+	sb.AppendValues([]string{"a", "b", "c"}, nil)
+	nonullValues := sb.NewArray()
+	defer nonullValues.Release()
+
+	tests := []struct {
+		name    string
+		indices []int16
+		valid   []bool
+		values  arrow.Array
+		// slice the dictionary array to [start, end) if end > 0
+		start, end int
+
+		expected      []string
+		expectedValid []bool
+	}{
+		{
+			name:          "null indices",
+			indices:       []int16{0, 1, 2, 0},
+			valid:         []bool{true, false, true, false},
+			values:        nonullValues,
+			expected:      []string{"a", "", "c", ""},
+			expectedValid: []bool{true, false, true, false},
+		},
+		{
+			name:          "null values",
+			indices:       []int16{0, 1, 2, 1},
+			values:        values,
+			expected:      []string{"a", "", "c", ""},
+			expectedValid: []bool{true, false, true, false},
+		},
+		{
+			name:          "null indices and values",
+			indices:       []int16{0, 1, 2, 0, 2},
+			valid:         []bool{true, true, true, false, true},
+			values:        values,
+			expected:      []string{"a", "", "c", "", "c"},
+			expectedValid: []bool{true, false, true, false, true},
+		},
+		{
+			name:          "sliced",
+			indices:       []int16{0, 1, 2, 0, 2, 1},
+			valid:         []bool{true, true, true, false, true, true},
+			values:        values,
+			start:         2,
+			end:           6,
+			expected:      []string{"c", "", "c", ""},
+			expectedValid: []bool{true, false, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newDictionary(t, mem, tt.indices, tt.valid, tt.values)
+			defer a.Release()
+			if tt.end > 0 {
+				sliced := array.NewSlice(a, int64(tt.start), int64(tt.end))
+				defer sliced.Release()
+				a = sliced
+			}
+
+			s, err := anyarrow.NewString(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Release()
+			if s.Len() != len(tt.expected) {
+				t.Fatalf("expected length %d, got %d", len(tt.expected), s.Len())
+			}
+
+			nulls := 0
+			for i, expected := range tt.expected {
+				if !tt.expectedValid[i] {
+					nulls++
+				}
+				if s.IsValid(i) != tt.expectedValid[i] || s.IsNull(i) == tt.expectedValid[i] {
+					t.Errorf("validity at %d: expected %t, got IsValid %t and IsNull %t", i, tt.expectedValid[i], s.IsValid(i), s.IsNull(i))
+				}
+				v, ok := s.ValueOk(i)
+				if ok != tt.expectedValid[i] || v != expected {
+					t.Errorf("ValueOk at %d: expected (%q, %t), got (%q, %t)", i, expected, tt.expectedValid[i], v, ok)
+				}
+			}
+			if s.NullN() != nulls {
+				t.Errorf("expected %d nulls, got %d", nulls, s.NullN())
+			}
+
+			n := 0
+			for i := range s.Valid() {
+				if !tt.expectedValid[i] {
+					t.Errorf("Valid yielded null element at %d", i)
+				}
+				n++
+			}
+			if n != len(tt.expected)-nulls {
+				t.Errorf("expected Valid to yield %d elements, got %d", len(tt.expected)-nulls, n)
+			}
+		})
+	}
+}
+
+func TestNullArray(t *testing.T) {
+	type Symbol string
+
+	mem := memory.NewGoAllocator()
+
+	nulls := array.NewNull(5)
+	defer nulls.Release()
+	dictionary := newDictionary(t, mem, []int16{0, 1, 1}, []bool{true, false, true}, nulls)
+	defer dictionary.Release()
+	sliced := array.NewSlice(nulls, 1, 4)
+	defer sliced.Release()
+
+	for _, arr := range []arrow.Array{nulls, dictionary, sliced} {
+		t.Run(arr.DataType().String(), func(t *testing.T) {
+			f, err := anyarrow.NewFloat64(arr, anyarrow.WithLossless(), anyarrow.WithDecodedDictionary())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Release()
+			s, err := anyarrow.NewStringOf[Symbol](arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Release()
+			b, err := anyarrow.NewBigInt(arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer b.Release()
+			iv, err := anyarrow.NewInterval(arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer iv.Release()
+
+			for i := range arr.Len() {
+				if f.IsValid(i) || s.IsValid(i) || b.IsValid(i) || iv.IsValid(i) {
+					t.Errorf("element %d is valid", i)
+				}
+				if f.Value(i) != 0 || s.Value(i) != "" || b.Value(i).Sign() != 0 || iv.Value(i) != (anyarrow.IntervalValue{}) {
+					t.Errorf("element %d is not the zero value", i)
+				}
+			}
+			for _, n := range []int{f.NullN(), s.NullN(), b.NullN(), iv.NullN()} {
+				if n != arr.Len() {
+					t.Errorf("expected %d nulls, got %d", arr.Len(), n)
+				}
+			}
+			if values := f.Values(); len(values) != arr.Len() || slices.ContainsFunc(values, func(x float64) bool { return x != 0 }) {
+				t.Errorf("expected %d zeros, got %v", arr.Len(), values)
+			}
+			for range f.Valid() {
+				t.Error("Valid yielded a null element")
+			}
+			if err := f.Validate(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	c, err := anyarrow.CanConvert(arrow.Null, reflect.TypeFor[int32]())
+	if err != nil || c.Kind != anyarrow.ConversionWidening {
+		t.Errorf("expected lossless widening, got %s, %v", c.Kind, err)
+	}
+	if toTime, err := anyarrow.TimeFunc(arrow.Null); err != nil || !toTime(1).IsZero() {
+		t.Errorf("expected null to convert to the zero time, got %v", err)
+	}
+	if toDuration, err := anyarrow.DurationFunc(arrow.Null); err != nil || toDuration(1) != 0 {
+		t.Errorf("expected null to convert to the zero duration, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package anyarrow
+
+// Nullable is an element of an [arrow.Array] that can be null,
+// in which case Valid is false.
+type Nullable[T any] struct {
+	Value T
+	Valid bool
+}
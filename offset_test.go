@@ -0,0 +1,171 @@
+package anyarrow_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func fromJSON(t *testing.T, mem memory.Allocator, dt arrow.DataType, s string) arrow.Array {
+	t.Helper()
+
+	a, _, err := array.FromJSON(mem, dt, strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("failed to create %s array: %v", dt, err)
+	}
+
+	return a
+}
+
+// checkInt64 checks the accessor of a against expected through all the ways of reading the elements.
+func checkInt64(t *testing.T, a *anyarrow.Int64, expected []int64) {
+	t.Helper()
+
+	if a.Len() != len(expected) {
+		t.Fatalf("expected length %d, got %d", len(expected), a.Len())
+	}
+	for i, v := range expected {
+		if got := a.Value(i); got != v {
+			t.Errorf("value at %d: expected %d, got %d", i, v, got)
+		}
+	}
+	if got := a.Values(); !slices.Equal(got, expected) {
+		t.Errorf("Values: expected %v, got %v", expected, got)
+	}
+	dst := make([]int64, len(expected)+1)
+	if n := a.CopyTo(dst); n != len(expected) || !slices.Equal(dst[:n], expected) {
+		t.Errorf("CopyTo: expected %v, got %v", expected, dst[:n])
+	}
+	for start, chunk := range a.Chunks(2) {
+		if !slices.Equal(chunk, expected[start:min(start+2, len(expected))]) {
+			t.Errorf("chunk at %d: expected %v, got %v", start, expected[start:min(start+2, len(expected))], chunk)
+		}
+	}
+}
+
+func TestSliced(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	numbers := "[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]"
+	expected := []int64{3, 4, 5, 6, 7}
+
+	types := []arrow.DataType{
+		arrow.PrimitiveTypes.Int8,
+		arrow.PrimitiveTypes.Int16,
+		arrow.PrimitiveTypes.Int32,
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Uint8,
+		arrow.PrimitiveTypes.Uint16,
+		arrow.PrimitiveTypes.Uint32,
+		arrow.PrimitiveTypes.Uint64,
+		arrow.PrimitiveTypes.Float32,
+		arrow.PrimitiveTypes.Float64,
+		arrow.PrimitiveTypes.Date32,
+		arrow.PrimitiveTypes.Date64,
+		arrow.FixedWidthTypes.Timestamp_s,
+		arrow.FixedWidthTypes.Duration_ms,
+		arrow.FixedWidthTypes.Time32s,
+		arrow.FixedWidthTypes.Time64us,
+		&arrow.Decimal128Type{Precision: 10, Scale: 0},
+		&arrow.Decimal256Type{Precision: 10, Scale: 0},
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Float64},
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint32, ValueType: arrow.PrimitiveTypes.Int64},
+	}
+
+	for _, dt := range types {
+		t.Run(dt.String(), func(t *testing.T) {
+			a := fromJSON(t, mem, dt, numbers)
+			defer a.Release()
+			sliced := array.NewSlice(a, 3, 8)
+			defer sliced.Release()
+
+			for _, opts := range [][]anyarrow.Option{nil, {anyarrow.WithDecodedDictionary()}} {
+				i64, err := anyarrow.NewInt64(sliced, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer i64.Release()
+				checkInt64(t, i64, expected)
+
+				resliced := i64.Slice(1, 4)
+				defer resliced.Release()
+				checkInt64(t, resliced, expected[1:4])
+			}
+		})
+	}
+
+	t.Run("bool", func(t *testing.T) {
+		a := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "[true, false, false, true, true, false, true]")
+		defer a.Release()
+		sliced := array.NewSlice(a, 3, 6)
+		defer sliced.Release()
+
+		i64, err := anyarrow.NewInt64(sliced)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer i64.Release()
+		checkInt64(t, i64, []int64{1, 1, 0})
+
+		resliced := i64.Slice(1, 3)
+		defer resliced.Release()
+		checkInt64(t, resliced, []int64{1, 0})
+	})
+}
+
+func TestSlicedString(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	strs := `["zero", "one", "two", "three", "four", "five", "six"]`
+	expected := []string{"two", "three", "four", "five"}
+
+	types := []arrow.DataType{
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.Binary,
+		arrow.BinaryTypes.LargeString,
+		arrow.BinaryTypes.LargeBinary,
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String},
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int64, ValueType: arrow.BinaryTypes.Binary},
+	}
+
+	for _, dt := range types {
+		t.Run(dt.String(), func(t *testing.T) {
+			valueType := dt
+			if d, ok := dt.(*arrow.DictionaryType); ok {
+				valueType = d.ValueType
+			}
+			json := strs
+			if valueType.ID() == arrow.BINARY || valueType.ID() == arrow.LARGE_BINARY {
+				// binary arrays are base64 encoded in json.
+				json = `["emVybw==", "b25l", "dHdv", "dGhyZWU=", "Zm91cg==", "Zml2ZQ==", "c2l4"]`
+			}
+			a := fromJSON(t, mem, dt, json)
+			defer a.Release()
+			sliced := array.NewSlice(a, 2, 6)
+			defer sliced.Release()
+
+			for _, opts := range [][]anyarrow.Option{nil, {anyarrow.WithDecodedDictionary()}} {
+				s, err := anyarrow.NewString(sliced, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer s.Release()
+				if got := s.Values(); !slices.Equal(got, expected) {
+					t.Errorf("expected %v, got %v", expected, got)
+				}
+
+				resliced := s.Slice(1, 3)
+				defer resliced.Release()
+				if got := resliced.Values(); !slices.Equal(got, expected[1:3]) {
+					t.Errorf("expected %v, got %v", expected[1:3], got)
+				}
+			}
+		})
+	}
+}
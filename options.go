@@ -0,0 +1,40 @@
+package anyarrow
+
+// Option configures the accessors created by the NewXxx functions.
+type Option func(*options)
+
+type options struct {
+	decodeDictionary bool
+	lossless         bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithDecodedDictionary decodes the values of a dictionary array into a slice once when the accessor is created,
+// so reading an element only costs a lookup of the index.
+//
+// This is useful for low cardinality dictionaries that are read repeatedly.
+// It has no effect on arrays that are not dictionaries.
+func WithDecodedDictionary() Option {
+	return func(o *options) {
+		o.decodeDictionary = true
+	}
+}
+
+// WithLossless only accepts arrays whose values are converted without loss,
+// which are the direct and widening conversions of [CanConvert] determined from the arrow type,
+// for example [NewInt64] accepts int32 arrays but [NewInt32] refuses int64 arrays.
+//
+// A [*LossyConversionError] is returned for arrays that would be narrowed.
+func WithLossless() Option {
+	return func(o *options) {
+		o.lossless = true
+	}
+}
@@ -0,0 +1,78 @@
+package anyarrow_test
+
+import (
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestRefCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		dt       arrow.DataType
+		json     string
+		opts     []anyarrow.Option
+		expected []int64
+	}{
+		{name: "direct", dt: arrow.PrimitiveTypes.Int64, json: "[1, 2, 3]", expected: []int64{1, 2, 3}},
+		{name: "cast", dt: arrow.PrimitiveTypes.Float32, json: "[1, 2, 3]", expected: []int64{1, 2, 3}},
+		{
+			name:     "dictionary",
+			dt:       &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int32},
+			json:     "[1, 2, 1]",
+			expected: []int64{1, 2, 1},
+		},
+		{
+			name:     "decoded dictionary",
+			dt:       &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int32},
+			json:     "[1, 2, 1]",
+			opts:     []anyarrow.Option{anyarrow.WithDecodedDictionary()},
+			expected: []int64{1, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			a := fromJSON(t, mem, tt.dt, tt.json)
+			i64, err := anyarrow.NewInt64(a, tt.opts...)
+			a.Release()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the accessor keeps the array alive after the original reference is released.
+			if mem.CurrentAlloc() == 0 {
+				t.Fatal("array is freed while the accessor is alive")
+			}
+			checkInt64(t, i64, tt.expected)
+
+			sliced := i64.Slice(1, 3)
+			i64.Retain()
+			i64.Release()
+			i64.Release()
+			if mem.CurrentAlloc() == 0 {
+				t.Fatal("array is freed while the sliced accessor is alive")
+			}
+			checkInt64(t, sliced, tt.expected[1:3])
+			sliced.Release()
+		})
+	}
+}
+
+func TestRefCountError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	a := fromJSON(t, mem, arrow.BinaryTypes.String, `["a"]`)
+	defer a.Release()
+
+	if _, err := anyarrow.NewInt64(a); err == nil {
+		t.Fatal("expected error for string array")
+	}
+}
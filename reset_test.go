@@ -0,0 +1,76 @@
+package anyarrow_test
+
+import (
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+func TestReset(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	first := fromJSON(t, mem, arrow.PrimitiveTypes.Int32, "[1, 2, 3]")
+	second := fromJSON(t, mem, arrow.PrimitiveTypes.Float64, "[4, 5]")
+	str := fromJSON(t, mem, arrow.BinaryTypes.String, `["a"]`)
+	defer str.Release()
+
+	i64, err := anyarrow.NewInt64(first)
+	first.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer i64.Release()
+	checkInt64(t, i64, []int64{1, 2, 3})
+
+	if err := i64.Reset(second); err != nil {
+		t.Fatal(err)
+	}
+	second.Release()
+	checkInt64(t, i64, []int64{4, 5})
+
+	if err := i64.Reset(str); err == nil {
+		t.Fatal("expected error for string array")
+	}
+	checkInt64(t, i64, []int64{4, 5})
+}
+
+func TestResetAllocs(t *testing.T) {
+	mem := memory.NewGoAllocator()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	dict := fromJSON(t, mem, arrow.BinaryTypes.String, `["a", "b", "c"]`)
+	defer dict.Release()
+
+	batches := make([]arrow.Array, 4)
+	for i := range batches {
+		indices := fromJSON(t, mem, arrow.PrimitiveTypes.Int8, "[0, 1, 2, 1, 0]")
+		batches[i] = array.NewDictionaryArray(dt, indices, dict)
+		indices.Release()
+		defer batches[i].Release()
+	}
+
+	s, err := anyarrow.NewString(batches[0], anyarrow.WithDecodedDictionary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Release()
+
+	n := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		n++
+		if err := s.Reset(batches[n%len(batches)]); err != nil {
+			t.Fatal(err)
+		}
+		if v := s.Value(2); v != "c" {
+			t.Fatalf("expected c, got %s", v)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected Reset to not allocate, got %v allocations", allocs)
+	}
+}
@@ -0,0 +1,124 @@
+package anyarrow
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+// Expectation is what a [Contract] expects of a column.
+type Expectation struct {
+	// GoType is the go type the column is read as, by the NewXxx function accepting it.
+	GoType reflect.Type
+	// Nullable allows the column to be nullable.
+	Nullable bool
+	// Lossy allows the values of the column to be narrowed, see [ConversionNarrowing].
+	Lossy bool
+}
+
+// Contract is the go side expectation of a schema, by column name.
+type Contract map[string]Expectation
+
+// ColumnProblem is why a column is incompatible with its [Expectation].
+type ColumnProblem uint8
+
+const (
+	// ColumnMissing indicates the schema has no column of the name.
+	ColumnMissing ColumnProblem = iota + 1
+	// ColumnUnsupported indicates the column cannot be read as the go type.
+	ColumnUnsupported
+	// ColumnLossy indicates the column can only be read as the go type by a lossy conversion.
+	ColumnLossy
+	// ColumnNullable indicates the column is nullable.
+	ColumnNullable
+)
+
+// ColumnError is an incompatibility between a column of a schema and its [Expectation], see [CheckSchema].
+type ColumnError struct {
+	Column  string
+	Problem ColumnProblem
+	// Conversion is the conversion of the column to the go type, unset for missing columns.
+	Conversion Conversion
+	// Err is the error of the NewXxx function for unsupported columns,
+	// and a [*LossyConversionError] for lossy columns as returned with [WithLossless].
+	Err error
+}
+
+func (e *ColumnError) Error() string {
+	switch e.Problem {
+	case ColumnMissing:
+		return fmt.Sprintf("column %q: missing", e.Column)
+	case ColumnUnsupported, ColumnLossy:
+		return fmt.Sprintf("column %q: %v", e.Column, e.Err)
+	default:
+		return fmt.Sprintf("column %q: nullable, but nulls are not expected", e.Column)
+	}
+}
+
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaError lists every incompatibility between a schema and a [Contract], see [CheckSchema].
+type SchemaError struct {
+	Columns []*ColumnError
+}
+
+func (e *SchemaError) Error() string {
+	lines := make([]string, 0, len(e.Columns)+1)
+	lines = append(lines, "schema is incompatible with the contract:")
+	for _, c := range e.Columns {
+		lines = append(lines, "\t"+c.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the [*ColumnError]s, so [errors.As] finds them and the errors they wrap.
+func (e *SchemaError) Unwrap() []error {
+	errs := make([]error, len(e.Columns))
+	for i, c := range e.Columns {
+		errs[i] = c
+	}
+
+	return errs
+}
+
+// CheckSchema checks schema against contract by the conversion rules of the accessors, see [CanConvert],
+// and returns a [*SchemaError] listing every incompatibility ordered by column name, or nil if there is none.
+//
+// Columns of the schema that are not in the contract are ignored,
+// and a column that appears more than once is checked by its first occurrence, as [Unmarshal] reads it.
+func CheckSchema(schema *arrow.Schema, contract Contract) error {
+	var columns []*ColumnError
+	for _, name := range slices.Sorted(maps.Keys(contract)) {
+		expected := contract[name]
+		k := schema.FieldIndices(name)
+		if len(k) == 0 {
+			columns = append(columns, &ColumnError{Column: name, Problem: ColumnMissing})
+			continue
+		}
+
+		field := schema.Field(k[0])
+		c, err := CanConvert(field.Type, expected.GoType)
+		switch {
+		case err != nil:
+			columns = append(columns, &ColumnError{Column: name, Problem: ColumnUnsupported, Conversion: c, Err: err})
+		case c.Kind == ConversionNarrowing && !expected.Lossy:
+			columns = append(columns, &ColumnError{Column: name, Problem: ColumnLossy, Conversion: c, Err: &LossyConversionError{Conversion: c}})
+		}
+		if field.Nullable && !expected.Nullable {
+			columns = append(columns, &ColumnError{Column: name, Problem: ColumnNullable, Conversion: c})
+		}
+	}
+
+	if len(columns) > 0 {
+		return &SchemaError{Columns: columns}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+func TestCheckSchema(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "symbol", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}},
+		{Name: "price", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "size", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "count", Type: arrow.PrimitiveTypes.Int16},
+		{Name: "venue", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	contract := anyarrow.Contract{
+		"symbol": {GoType: reflect.TypeFor[string]()},
+		"price":  {GoType: reflect.TypeFor[Price](), Nullable: true},
+		"count":  {GoType: reflect.TypeFor[int64]()},
+	}
+	if err := anyarrow.CheckSchema(schema, contract); err != nil {
+		t.Fatalf("expected compatible schema, got %v", err)
+	}
+
+	contract = anyarrow.Contract{
+		"symbol": {GoType: reflect.TypeFor[int64]()},
+		"price":  {GoType: reflect.TypeFor[float32](), Lossy: true},
+		"size":   {GoType: reflect.TypeFor[int32]()},
+		"time":   {GoType: reflect.TypeFor[int64]()},
+		"venue":  {GoType: reflect.TypeFor[string]()},
+	}
+	err := anyarrow.CheckSchema(schema, contract)
+	var schemaErr *anyarrow.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+
+	expected := []struct {
+		column  string
+		problem anyarrow.ColumnProblem
+	}{
+		{"price", anyarrow.ColumnNullable},
+		{"size", anyarrow.ColumnLossy},
+		{"symbol", anyarrow.ColumnUnsupported},
+		{"time", anyarrow.ColumnMissing},
+		{"venue", anyarrow.ColumnNullable},
+	}
+	if len(schemaErr.Columns) != len(expected) {
+		t.Fatalf("expected %d incompatibilities, got %v", len(expected), err)
+	}
+	for i, e := range expected {
+		if c := schemaErr.Columns[i]; c.Column != e.column || c.Problem != e.problem {
+			t.Errorf("expected problem %d with column %q at %d, got %v", e.problem, e.column, i, c)
+		}
+	}
+
+	var dictErr *anyarrow.UnsupportedDictionaryError
+	if !errors.As(err, &dictErr) {
+		t.Errorf("expected the unsupported column to wrap UnsupportedDictionaryError, got %v", err)
+	}
+	expectedMessage := `schema is incompatible with the contract:
+	column "price": nullable, but nulls are not expected
+	column "size": cannot use int64 for gotype int32 without loss
+	column "symbol": cannot use dictionary with int32 indices and utf8 values for gotype int64
+	column "time": missing
+	column "venue": nullable, but nulls are not expected`
+	if err.Error() != expectedMessage {
+		t.Errorf("expected message\n%s\ngot\n%s", expectedMessage, err)
+	}
+}
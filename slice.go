@@ -0,0 +1,40 @@
+package anyarrow
+
+import "unsafe"
+
+// number is the set of types whose elements can be converted between each other by a simple go conversion.
+type number interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// convertSlice converts the elements of src into dst, and returns the number of elements converted,
+// which is the minimum of len(dst) and len(src).
+func convertSlice[D, S number](dst []D, src []S) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, v := range src {
+		dst[i] = D(v)
+	}
+
+	return n
+}
+
+// reinterpretSlice returns a slice of D that shares the memory with src.
+// D and S must have the same size and the conversion between them must keep the bits unchanged.
+func reinterpretSlice[D, S any](src []S) []D {
+	return unsafe.Slice((*D)(unsafe.Pointer(unsafe.SliceData(src))), len(src))
+}
+
+// gather sets dst[i] to values[indices[i]], and returns the number of elements set,
+// which is the minimum of len(dst) and len(indices).
+func gather[I ~uint8 | ~uint16 | ~uint32 | ~uint64, T any](dst []T, indices []I, values []T) int {
+	n := min(len(dst), len(indices))
+	dst, indices = dst[:n], indices[:n]
+	for i, idx := range indices {
+		dst[i] = values[idx]
+	}
+
+	return n
+}
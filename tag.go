@@ -0,0 +1,78 @@
+package anyarrow
+
+import (
+	"reflect"
+
+	"github.com/fardream/anyarrow/internal/tag"
+)
+
+// structField is a field of a go struct that is mapped to an arrow column.
+type structField struct {
+	// name is the name of the arrow column.
+	name string
+	// index is the index sequence of the field for [reflect.Value.FieldByIndex].
+	index []int
+	typ   reflect.Type
+	// options are the comma separated options following the name in the tag.
+	options []string
+}
+
+// structFields returns the fields of struct type t that are mapped to arrow columns.
+//
+// The column name of a field is the name in its `arrow:"name"` tag, or the field name if there is no tag.
+// Fields tagged with `arrow:"-"` and unexported fields are skipped,
+// and fields of embedded structs without a tag are promoted like encoding/json does,
+// except for embedded pointers to unexported structs, which are skipped.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tagValue, hasTag := f.Tag.Lookup(tag.Key)
+		if tagValue == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			// pointers to unexported structs cannot be allocated when decoding.
+			if !f.IsExported() {
+				continue
+			}
+			ft = ft.Elem()
+		}
+		if f.Anonymous && !hasTag && ft.Kind() == reflect.Struct {
+			for _, sf := range structFields(ft) {
+				sf.index = append([]int{i}, sf.index...)
+				fields = append(fields, sf)
+			}
+
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		name, options := tag.Parse(tagValue)
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{name: name, index: []int{i}, typ: f.Type, options: options})
+	}
+
+	return fields
+}
+
+// fieldByIndex returns the field of struct v by index, allocating the embedded struct pointers that are nil.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
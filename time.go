@@ -0,0 +1,52 @@
+package anyarrow
+
+import (
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+)
+
+// TimeFunc returns the function converting the values of timestamp, date32 and date64 arrays of type dt,
+// as read by [Int64], to time.Time. For dictionaries, the value type of dt is used.
+// Null arrays, whose values are all null, are converted to the zero time.Time.
+func TimeFunc(dt arrow.DataType) (func(int64) time.Time, error) {
+	if d, ok := dt.(*arrow.DictionaryType); ok {
+		dt = d.ValueType
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.TimestampType:
+		f, err := dt.GetToTimeFunc()
+		if err != nil {
+			return nil, err
+		}
+		return func(x int64) time.Time { return f(arrow.Timestamp(x)) }, nil
+	case *arrow.Date32Type:
+		return func(x int64) time.Time { return arrow.Date32(x).ToTime() }, nil
+	case *arrow.Date64Type:
+		return func(x int64) time.Time { return arrow.Date64(x).ToTime() }, nil
+	case *arrow.NullType:
+		return func(int64) time.Time { return time.Time{} }, nil
+	default:
+		return nil, &UnsupportedTypeError{DataType: dt, GoType: timeType}
+	}
+}
+
+// DurationFunc returns the function converting the values of duration arrays of type dt,
+// as read by [Int64], to time.Duration. For dictionaries, the value type of dt is used.
+// Null arrays, whose values are all null, are converted to zero.
+func DurationFunc(dt arrow.DataType) (func(int64) time.Duration, error) {
+	if d, ok := dt.(*arrow.DictionaryType); ok {
+		dt = d.ValueType
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.DurationType:
+		unit := time.Duration(dt.Unit.Multiplier())
+		return func(x int64) time.Duration { return time.Duration(x) * unit }, nil
+	case *arrow.NullType:
+		return func(int64) time.Duration { return 0 }, nil
+	default:
+		return nil, &UnsupportedTypeError{DataType: dt, GoType: durationType}
+	}
+}
@@ -0,0 +1,92 @@
+package anyarrow_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fardream/anyarrow"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// validate validates the accessor a, returning err if a couldn't be created.
+func validate[A interface {
+	Validate() error
+	Release()
+}](a A, err error) error {
+	if err != nil {
+		return err
+	}
+	defer a.Release()
+
+	return a.Validate()
+}
+
+func TestValidate(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	int64s := anyarrow.FromSlice([]int64{1, 3_000_000_000, -5, 7}, []bool{true, false, true, true})
+	defer int64s.Release()
+	large := anyarrow.FromSlice([]int64{1, 3_000_000_000}, nil)
+	defer large.Release()
+	uint64s := anyarrow.FromSlice([]uint64{1, math.MaxUint64}, nil)
+	defer uint64s.Release()
+	floats := anyarrow.FromSlice([]float64{1, -2, 2.5, math.NaN(), 1e300}, nil)
+	defer floats.Release()
+	int16s := anyarrow.FromSlice([]int16{-1, 1}, nil)
+	defer int16s.Release()
+
+	db := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 10, Scale: 2})
+	defer db.Release()
+	db.AppendValues([]decimal128.Num{decimal128.FromI64(100), decimal128.FromI64(-200), decimal128.FromI64(150)}, nil)
+	decimals := db.NewArray()
+	defer decimals.Release()
+
+	dict := newDictionary(t, mem, []int16{0, 0, 1, 1}, []bool{true, true, false, true}, large)
+	defer dict.Release()
+
+	sliced := array.NewSlice(large, 0, 1)
+	defer sliced.Release()
+	integral := array.NewSlice(floats, 0, 2)
+	defer integral.Release()
+	nan := array.NewSlice(floats, 3, 4)
+	defer nan.Release()
+
+	tests := []struct {
+		name  string
+		err   error
+		index int
+	}{
+		{"int64 with nulls to int32", validate(anyarrow.NewInt32(int64s)), -1},
+		{"int64 to uint8", validate(anyarrow.NewUint8(int64s)), 2},
+		{"int64 to int32", validate(anyarrow.NewInt32(large)), 1},
+		{"sliced int64 to int32", validate(anyarrow.NewInt32(sliced)), -1},
+		{"uint64 to int64", validate(anyarrow.NewInt64(uint64s)), 1},
+		{"float64 to int64", validate(anyarrow.NewInt64(integral)), -1},
+		{"fraction to int64", validate(anyarrow.NewInt64(floats)), 2},
+		{"float64 to float32", validate(anyarrow.NewFloat32(floats)), 4},
+		{"NaN to float32", validate(anyarrow.NewFloat32(nan)), -1},
+		{"int16 to int64", validate(anyarrow.NewInt64(int16s)), -1},
+		{"int16 to uint16", validate(anyarrow.NewUint16(int16s)), 0},
+		{"decimal to int32", validate(anyarrow.NewInt32(decimals)), 2},
+		{"decimal to float64", validate(anyarrow.NewFloat64(decimals)), -1},
+		{"dictionary to int32", validate(anyarrow.NewInt32(dict)), 3},
+	}
+
+	for _, test := range tests {
+		var overflowErr *anyarrow.OverflowError
+		switch {
+		case test.index < 0 && test.err != nil:
+			t.Errorf("%s: unexpected error %v", test.name, test.err)
+		case test.index >= 0 && !errors.As(test.err, &overflowErr):
+			t.Errorf("%s: expected OverflowError at %d, got %v", test.name, test.index, test.err)
+		case test.index >= 0 && overflowErr.Index != test.index:
+			t.Errorf("%s: expected OverflowError at %d, got %v", test.name, test.index, test.err)
+		}
+	}
+}